@@ -38,8 +38,11 @@ func main() {
 		return
 	}
 
+	engine := ai.NewEngine(0)
+	defer engine.Close()
+
 	var (
-		repo     CompetitorRepo = DBCompetitorRepo{DB: db}
+		repo     CompetitorRepo = DBCompetitorRepo{DB: db, Engine: engine}
 		database Database       = db
 	)
 
@@ -63,6 +66,8 @@ type CompetitorRepo interface {
 
 type DBCompetitorRepo struct {
 	DB *persist.DB
+	// Engine runs the searches of every competitor
+	Engine *ai.Engine
 }
 
 func (d DBCompetitorRepo) CompetitorPairs() ([]CompetitorPair, error) {
@@ -81,23 +86,27 @@ func (d DBCompetitorRepo) CompetitorPairs() ([]CompetitorPair, error) {
 	var output []CompetitorPair
 	for _, matchup := range matchups {
 		output = append(output, CompetitorPair{
-			Competitor1: nameToAi(matchup.Player1),
-			Competitor2: nameToAi(matchup.Player2),
+			Competitor1: nameToAi(d.Engine, matchup.Player1),
+			Competitor2: nameToAi(d.Engine, matchup.Player2),
 			NumPlays:    matchup.NumGames,
 		})
 	}
 	return output, nil
 }
 
-func nameToAi(name string) ai.AI {
-	switch  {
+func nameToAi(engine *ai.Engine, name string) ai.AI {
+	switch {
 	case strings.HasPrefix(name, "Smarty"):
-		return ai.NewSmartyAI(wordDB, wordDB)
+		return engine.NewSmartyAI(wordDB, wordDB)
 	case strings.HasPrefix(name, "Speedy"):
-		return ai.NewSpeedyAI(wordDB, wordGaddag)
+		return engine.NewSpeedyAI(wordDB, wordGaddag)
+	case strings.HasPrefix(name, "Anchor"):
+		return ai.NewAnchorAI(wordDB, wordDB)
+	case strings.HasPrefix(name, "Sim"):
+		return ai.NewSimulator(ai.NewAnchorAI(wordDB, wordDB), ai.NewEquity(nil))
 	}
 	log.Printf("Failed to decode ai name %q, defaulting to smarty", name)
-	return ai.NewSmartyAI(wordDB, wordDB)
+	return engine.NewSmartyAI(wordDB, wordDB)
 }
 
 func getConnectionString() (string, error) {
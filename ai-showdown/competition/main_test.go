@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/internal/leaktest"
+	"github.com/Logiraptor/word-bot/persist"
+	"github.com/stretchr/testify/assert"
+)
+
+type pairRepo []CompetitorPair
+
+func (p pairRepo) CompetitorPairs() ([]CompetitorPair, error) {
+	return p, nil
+}
+
+type gameRecorder []persist.Game
+
+func (g *gameRecorder) SaveGame(game persist.Game) error {
+	*g = append(*g, game)
+	return nil
+}
+
+func TestRunIterationLeaks(t *testing.T) {
+	defer leaktest.Check(t)()
+	engine := ai.NewEngine(0)
+	repo := pairRepo{
+		{Competitor1: nameToAi(engine, "Smarty"), Competitor2: nameToAi(engine, "Speedy")},
+	}
+	var games gameRecorder
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, runIteration(repo, wordDB, &games))
+	}
+	engine.Close()
+	if assert.Len(t, games, 2) {
+		assert.NotEmpty(t, games[0].Moves)
+		assert.NotEmpty(t, games[1].Moves)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -18,12 +19,13 @@ import (
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 )
 
+var lexiconName = flag.String("lexicon", wordlist.Default.Name, "lexicon to play with, see "+wordlist.LexiconDirEnv)
+
+var lexicon *wordlist.Lexicon
 var wordDB *wordlist.Trie
 var commitHash []byte
 
 func init() {
-	wordDB = wordlist.MakeDefaultWordList()
-
 	status, err := exec.Command("git", "status").Output()
 	if err != nil {
 		panic(err)
@@ -42,9 +44,22 @@ func init() {
 }
 
 func main() {
-
+	flag.Parse()
 	rand.Seed(time.Now().Unix())
 
+	lexicons, err := wordlist.LoadRegistry()
+	if err != nil {
+		panic(err)
+	}
+	lexicon, err = lexicons.Lookup(*lexiconName)
+	if err != nil {
+		panic(err)
+	}
+	wordDB, err = lexicon.Trie()
+	if err != nil {
+		panic(err)
+	}
+
 	db, err := persist.NewDB("smart-results.db")
 	if err != nil {
 		panic(err)
@@ -60,7 +75,11 @@ func main() {
 		go worker(db, &wg, jobs)
 	}
 
-	smarty := ai.NewSmartyAI(wordDB, wordDB)
+	engine := ai.NewEngine(0)
+	defer engine.Close()
+	// validating with the lexicon PlayGame plays with lets moves reuse the
+	// board's cross-checks
+	smarty := engine.NewSmartyAI(lexicon, wordDB)
 	weighted := ai.NewMoveChooser("Weighted - From Data"+time.Now().Format("02-15:04"), smarty, ai.NewLeaveWeighter(db))
 	numIterations := 1000
 	for i := 0; i < numIterations; i++ {
@@ -89,7 +108,7 @@ type Job struct {
 
 func worker(db *persist.DB, wg *sync.WaitGroup, jobs <-chan Job) {
 	for j := range jobs {
-		g := ai.PlayGame(wordDB, j.p1, j.p2)
+		g := ai.PlayGame(lexicon, j.p1, j.p2)
 		err := db.SaveGame(g)
 		if err != nil {
 			fmt.Println("ERROR Saving Game", err)
@@ -1,19 +1,32 @@
 package ai
 
-import "github.com/Logiraptor/word-bot/core"
+import (
+	"context"
+
+	"github.com/Logiraptor/word-bot/core"
+)
 
 // AI can automate gameplay
 type AI interface {
-	// onMove will be called with increasingly valuable moves until it returns false or
-	// all moves have been generated
-	FindMove(b *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool)
+	// onMove will be called with increasingly valuable moves until it returns false,
+	// ctx is done or all moves have been generated
+	FindMove(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool)
 	Name() string
 }
 
-// MoveGenerator will generate moves, calling onMove until it returns false
-// Or all moves have been generated.
+// MoveGenerator will generate moves, calling onMove until it returns false,
+// ctx is done or all moves have been generated. Each distinct move is
+// generated once, in the form returned by Board.CanonicalMove. Generators
+// don't use the board after GenerateMoves returns.
 type MoveGenerator interface {
-	GenerateMoves(b *core.Board, rack core.Rack, onMove func(core.Turn) bool)
+	GenerateMoves(ctx context.Context, b *core.Board, rack core.Rack, onMove func(core.Turn) bool)
+}
+
+// crossCheckGenerator is implemented by move generators which validate moves
+// with cross-checks for a word list. Boards with cross-checks enabled for it
+// are searched without being copied.
+type crossCheckGenerator interface {
+	crossCheckWords() core.WordList
 }
 
 // A BoardEvaluator determines a heuristic score for a board position
@@ -24,3 +37,10 @@ type BoardEvaluator interface {
 type MoveEvaluator interface {
 	Evaluate(b *core.Board, rack core.Rack, move core.ScoredMove) float64
 }
+
+// An ExchangeEvaluator can also value exchanges, which keep some of the rack
+// without placing anything on the board
+type ExchangeEvaluator interface {
+	MoveEvaluator
+	EvaluateExchange(b *core.Board, rack core.Rack, exchange core.Exchange) float64
+}
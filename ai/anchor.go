@@ -0,0 +1,270 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+)
+
+// AnchorAI generates moves with the algorithm from Appel and Jacobson, "The
+// World's Fastest Scrabble Program". Each move is built from the leftmost
+// anchor it covers: a left part is placed on the empty squares before the
+// anchor, or read from the tiles already there, and then extended right
+// through the anchor. Cross-checks prune every placement, so only legal
+// moves are found, and they are scored as they are built.
+type AnchorAI struct {
+	wordList    core.WordList
+	searchSpace *wordlist.Trie
+}
+
+var _ AI = &AnchorAI{}
+var _ MoveGenerator = &AnchorAI{}
+var _ crossCheckGenerator = &AnchorAI{}
+
+func NewAnchorAI(wordList core.WordList, searchSpace *wordlist.Trie) *AnchorAI {
+	return &AnchorAI{
+		wordList:    wordList,
+		searchSpace: searchSpace,
+	}
+}
+
+func (a *AnchorAI) FindMove(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, callback func(core.Turn) bool) {
+	var bestMove core.ScoredMove
+	a.GenerateMoves(ctx, b, rack, func(turn core.Turn) bool {
+		switch x := turn.(type) {
+		case core.ScoredMove:
+			if x.Score > bestMove.Score {
+				bestMove = x
+				return callback(x)
+			}
+		}
+		return true
+	})
+}
+
+// GenerateMoves finds every legal move once, stopping early if callback
+// returns false or ctx is done. ctx is checked before each anchor.
+func (a *AnchorAI) GenerateMoves(ctx context.Context, b *core.Board, rack core.Rack, callback func(core.Turn) bool) {
+	b = b.WithCrossChecks(a.wordList)
+	s := anchorSearch{board: b, callback: callback}
+	for i := range rack.Rack {
+		if rack.CanConsume(i) {
+			s.rackSize++
+		}
+	}
+	for _, dir := range []core.Direction{core.Horizontal, core.Vertical} {
+		s.dir = dir
+		s.dRow, s.dCol = dir.Offsets()
+		for _, anchor := range b.Anchors() {
+			if ctx.Err() != nil {
+				return
+			}
+			s.searchAnchor(a.searchSpace, anchor, rack)
+			if s.stopped {
+				return
+			}
+		}
+	}
+}
+
+func (a *AnchorAI) Name() string {
+	return "Anchor"
+}
+
+func (a *AnchorAI) crossCheckWords() core.WordList {
+	return a.wordList
+}
+
+// anchorSearch holds the state of one GenerateMoves call
+type anchorSearch struct {
+	board      *core.Board
+	dir        core.Direction
+	dRow, dCol int
+	rackSize   int
+	callback   func(core.Turn) bool
+	stopped    bool
+
+	// tiles holds the tiles placed so far, the left part first
+	tiles  []core.Tile
+	anchor core.Square
+	left   int
+}
+
+// partialScore is the score of a move as it is built
+type partialScore struct {
+	main, cross core.Score
+	multiplier  core.Bonus
+}
+
+func (s *anchorSearch) searchAnchor(root *wordlist.Trie, anchor core.Square, rack core.Rack) {
+	s.anchor = anchor
+	row, col := anchor.Row-s.dRow, anchor.Col-s.dCol
+	if s.board.HasTile(row, col) {
+		// the left part is already on the board
+		length := 0
+		for s.board.HasTile(row, col) {
+			row, col = row-s.dRow, col-s.dCol
+			length++
+		}
+		node, score := root, partialScore{multiplier: 1}
+		for i := 0; i < length; i++ {
+			row, col = row+s.dRow, col+s.dCol
+			t := s.board.TileAt(row, col)
+			var ok bool
+			if node, ok = node.CanBranch(t); !ok {
+				return
+			}
+			score.main += s.board.TileSet.PointValue(t)
+		}
+		s.left = 0
+		s.extendRight(node, anchor.Row, anchor.Col, rack, score, length)
+		return
+	}
+
+	// left parts may cover the empty squares up to the previous anchor,
+	// leaving at least one tile to place on this one
+	limit := 0
+	for limit < s.rackSize-1 && !s.board.OutOfBounds(row, col) && !s.board.IsAnchor(row, col) {
+		limit++
+		row, col = row-s.dRow, col-s.dCol
+	}
+	s.leftPart(root, rack, limit)
+}
+
+func (s *anchorSearch) leftPart(node *wordlist.Trie, rack core.Rack, limit int) {
+	if s.stopped {
+		return
+	}
+	s.left = len(s.tiles)
+	score := partialScore{multiplier: 1}
+	row, col := s.anchor.Row-s.left*s.dRow, s.anchor.Col-s.left*s.dCol
+	for _, t := range s.tiles {
+		score = s.place(score, t, row, col)
+		row, col = row+s.dRow, col+s.dCol
+	}
+	s.extendRight(node, s.anchor.Row, s.anchor.Col, rack, score, s.left)
+	if limit == 0 {
+		return
+	}
+	// squares before an anchor which aren't anchors have no neighbors, so
+	// any letter fits
+	s.eachTile(node, rack, core.AllLetters, func(t core.Tile, next *wordlist.Trie, rest core.Rack) {
+		s.tiles = append(s.tiles, t)
+		s.leftPart(next, rest, limit-1)
+		s.tiles = s.tiles[:len(s.tiles)-1]
+	})
+}
+
+// extendRight continues the word at row, col. length counts the letters of
+// the word so far.
+func (s *anchorSearch) extendRight(node *wordlist.Trie, row, col int, rack core.Rack, score partialScore, length int) {
+	if s.stopped {
+		return
+	}
+	if s.board.HasTile(row, col) {
+		t := s.board.TileAt(row, col)
+		if next, ok := node.CanBranch(t); ok {
+			score.main += s.board.TileSet.PointValue(t)
+			s.extendRight(next, row+s.dRow, col+s.dCol, rack, score, length+1)
+		}
+		return
+	}
+
+	pastAnchor := row != s.anchor.Row || col != s.anchor.Col
+	if pastAnchor && length > 1 && node.IsTerminal() {
+		s.emit(score)
+	}
+	if s.board.OutOfBounds(row, col) {
+		return
+	}
+	s.eachTile(node, rack, s.board.CrossCheck(row, col, s.dir), func(t core.Tile, next *wordlist.Trie, rest core.Rack) {
+		s.tiles = append(s.tiles, t)
+		s.extendRight(next, row+s.dRow, col+s.dCol, rest, s.place(score, t, row, col), length+1)
+		s.tiles = s.tiles[:len(s.tiles)-1]
+	})
+}
+
+// eachTile calls f for each distinct tile on the rack, and each letter a
+// blank could be, which node branches on and allowed contains
+func (s *anchorSearch) eachTile(node *wordlist.Trie, rack core.Rack, allowed core.LetterSet, f func(core.Tile, *wordlist.Trie, core.Rack)) {
+	var tried core.LetterSet
+	triedBlank := false
+	for i, t := range rack.Rack {
+		if !rack.CanConsume(i) {
+			continue
+		}
+		if t.IsBlank() {
+			if triedBlank {
+				continue
+			}
+			triedBlank = true
+			for r := blankA; r <= lastBlank; r++ {
+				if !allowed.Contains(r.ToLetter()) {
+					continue
+				}
+				if next, ok := node.CanBranch(r); ok {
+					f(r, next, rack.Consume(i))
+				}
+			}
+			continue
+		}
+
+		l := t.ToLetter()
+		if tried.Contains(l) || !allowed.Contains(l) {
+			continue
+		}
+		tried |= 1 << uint(l)
+		if next, ok := node.CanBranch(t); ok {
+			f(l.ToTile(false), next, rack.Consume(i))
+		}
+	}
+}
+
+// place adds a tile placed at row, col to the score, like Board.Score does
+func (s *anchorSearch) place(score partialScore, t core.Tile, row, col int) partialScore {
+	value := s.board.TileSet.PointValue(t)
+	letterBonus, wordBonus := core.Bonus(1), core.Bonus(1)
+	switch s.board.BonusAt(row, col) {
+	case core.DoubleLetter:
+		letterBonus = 2
+	case core.TripleLetter:
+		letterBonus = 3
+	case core.DoubleWord:
+		wordBonus = 2
+	case core.TripleWord:
+		wordBonus = 3
+	}
+	score.main += value * letterBonus
+	score.multiplier *= wordBonus
+	if s.board.CrossCheck(row, col, s.dir) != core.AllLetters {
+		score.cross += (s.board.CrossScore(row, col, s.dir) + value*letterBonus) * wordBonus
+	}
+	return score
+}
+
+func (s *anchorSearch) emit(score partialScore) {
+	row, col := s.anchor.Row-s.left*s.dRow, s.anchor.Col-s.left*s.dCol
+	if len(s.tiles) == 1 && s.dir == core.Vertical && (s.board.HasTile(row, col-1) || s.board.HasTile(row, col+1)) {
+		// the horizontal search finds single tiles joining words both ways
+		return
+	}
+	word := make([]core.Tile, len(s.tiles))
+	copy(word, s.tiles)
+	total := score.main*score.multiplier + score.cross
+	if len(word) >= s.board.TileSet.RackSize {
+		total += s.board.TileSet.BingoBonus
+	}
+	move := core.ScoredMove{
+		PlacedTiles: core.PlacedTiles{
+			Word:      word,
+			Row:       row,
+			Col:       col,
+			Direction: s.dir,
+		},
+		Score: total,
+	}
+	if !s.callback(move) {
+		s.stopped = true
+	}
+}
@@ -0,0 +1,114 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnchorMoveGen(t *testing.T) {
+	MoveGeneratorContract(t, func(words []string) ai.MoveGenerator {
+		wordDB := wordlist.NewTrie()
+
+		for _, word := range words {
+			wordDB.AddWord(word)
+		}
+
+		return ai.NewAnchorAI(wordDB, wordDB)
+	})
+}
+
+func TestAnchorMatchesSmartyAndBruteOnContractBoards(t *testing.T) {
+	for _, tc := range moveGenTestData {
+		t.Run(tc.name, func(t *testing.T) {
+			dictionary := wordlist.NewTrie()
+			for _, word := range tc.dictionary {
+				dictionary.AddWord(word)
+			}
+			board := core.NewBoard(core.ScrabbleLayout)
+			for _, m := range tc.previousMoves {
+				board.PlaceTiles(m)
+			}
+			rack := core.NewConsumableRack(tiles(tc.rack))
+
+			smarty := ai.NewSmartyAI(dictionary, dictionary)
+			defer smarty.Kill()
+			assertSameMoves(t, dictionary, board, rack, smarty, ai.NewAnchorAI(dictionary, dictionary))
+			assertSameMoves(t, dictionary, board, rack, ai.NewBrute(dictionary), ai.NewAnchorAI(dictionary, dictionary))
+		})
+	}
+}
+
+func TestAnchorMatchesSmarty(t *testing.T) {
+	rack := core.NewConsumableRack(core.MakeTiles(core.MakeWord("asdjdha"), "xxxxxx "))
+	board := core.NewBoard(core.ScrabbleLayout)
+
+	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("doggo"), "xxxxx"), 7, 7, core.Horizontal})
+	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("ar"), "xx"), 7, 8, core.Vertical})
+
+	smarty := ai.NewSmartyAI(wordDB, wordDB)
+	defer smarty.Kill()
+	assertSameMoves(t, wordDB, board, rack, smarty, ai.NewAnchorAI(wordDB, wordDB))
+}
+
+func TestAnchorMatchesSmartyOnFirstMove(t *testing.T) {
+	rack := core.NewConsumableRack(core.MakeTiles(core.MakeWord("retains"), "xxxxxxx"))
+	board := core.NewBoard(core.ScrabbleLayout)
+
+	smarty := ai.NewSmartyAI(wordDB, wordDB)
+	defer smarty.Kill()
+	assertSameMoves(t, wordDB, board, rack, smarty, ai.NewAnchorAI(wordDB, wordDB))
+}
+
+// assertSameMoves checks that both generators find the same moves with the
+// same scores, and that every move the second finds is legal
+func assertSameMoves(t *testing.T, wordList core.WordList, board *core.Board, rack core.Rack, want, got ai.MoveGenerator) {
+	var wantMoves, gotMoves []core.Turn
+	want.GenerateMoves(context.Background(), board, rack, func(t core.Turn) bool {
+		wantMoves = append(wantMoves, t)
+		return true
+	})
+	got.GenerateMoves(context.Background(), board, rack, func(t core.Turn) bool {
+		gotMoves = append(gotMoves, t)
+		return true
+	})
+
+	for _, turn := range gotMoves {
+		m := turn.(core.ScoredMove)
+		assert.NoError(t, board.ValidateMoveDetailed(m.PlacedTiles, wordList), "%v", m)
+		assert.Equal(t, board.Score(m.PlacedTiles), m.Score, "%v", m)
+	}
+
+	assert.ElementsMatch(t, wantMoves, gotMoves)
+}
+
+func TestAnchorStopsWhenAsked(t *testing.T) {
+	rack := core.NewConsumableRack(core.MakeTiles(core.MakeWord("retains"), "xxxxxxx"))
+	board := core.NewBoard(core.ScrabbleLayout)
+
+	calls := 0
+	ai.NewAnchorAI(wordDB, wordDB).GenerateMoves(context.Background(), board, rack, func(core.Turn) bool {
+		calls++
+		return calls < 3
+	})
+	assert.Equal(t, 3, calls)
+}
+
+func BenchmarkAnchor(b *testing.B) {
+	tiles := core.NewConsumableRack(core.MakeTiles(core.MakeWord("bdhrigs"), "xxxxxx "))
+	board := core.NewBoard(core.ScrabbleLayout)
+	anchor := ai.NewAnchorAI(wordDB, wordDB)
+	bag := core.NewConsumableBag(core.EnglishScrabble)
+
+	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 0, 7, core.Vertical})
+	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 7, 0, core.Horizontal})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		anchor.FindMove(context.Background(), board, bag, tiles, func(core.Turn) bool { return true })
+	}
+}
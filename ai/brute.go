@@ -1,6 +1,8 @@
 package ai
 
 import (
+	"context"
+
 	"github.com/Logiraptor/word-bot/core"
 )
 
@@ -14,8 +16,8 @@ func NewBrute(wordDB core.WordList) BruteForceGenerator {
 	return BruteForceGenerator{wordDB}
 }
 
-func (b BruteForceGenerator) FindMove(board *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool) {
-	b.GenerateMoves(board, rack, func(turn core.Turn) bool {
+func (b BruteForceGenerator) FindMove(ctx context.Context, board *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool) {
+	b.GenerateMoves(ctx, board, rack, func(turn core.Turn) bool {
 		return onMove(turn)
 	})
 }
@@ -24,23 +26,23 @@ func (b BruteForceGenerator) Name() string {
 	return "brute"
 }
 
-func (b BruteForceGenerator) GenerateMoves(board *core.Board, rack core.Rack, onMove func(core.Turn) bool) {
-	shouldEmit := true
-	BruteForce(board, rack, b.wordDB, func(t core.Turn) {
-		if shouldEmit {
-			shouldEmit = onMove(t)
-		}
-	})
+func (b BruteForceGenerator) GenerateMoves(ctx context.Context, board *core.Board, rack core.Rack, onMove func(core.Turn) bool) {
+	BruteForce(ctx, board, rack, b.wordDB, distinctMoves(board, onMove))
 }
 
-func BruteForce(b *core.Board, rack core.Rack, wordDB core.WordList, callback func(core.Turn)) {
+// BruteForce tries every permutation of the rack on every square, calling
+// callback with each legal move until it returns false or ctx is done
+func BruteForce(ctx context.Context, b *core.Board, rack core.Rack, wordDB core.WordList, callback func(core.Turn) bool) {
 	dirs := []core.Direction{core.Horizontal, core.Vertical}
 	perms := Permute(rack.Rack) // allocating a bunch of unnecessary memory
-	for i := 0; i < 15; i++ { // bunch of unrelated work happening serially
-		for j := 0; j < 15; j++ {
+	for i := 0; i < b.Layout.Rows; i++ { // bunch of unrelated work happening serially
+		for j := 0; j < b.Layout.Cols; j++ {
 			if b.HasTile(i, j) {
 				continue // skipping used spaces (the minority of spaces)
 			}
+			if ctx.Err() != nil {
+				return
+			}
 
 			for _, dir := range dirs {
 				for _, p := range perms {
@@ -48,11 +50,11 @@ func BruteForce(b *core.Board, rack core.Rack, wordDB core.WordList, callback fu
 					pt := core.PlacedTiles{Word: p, Row: i, Col: j, Direction: dir}
 					if b.ValidateMove(pt, wordDB) { // no information is kept across iterations
 						_, canPlay := rack.Play(p)
-						if canPlay {
-							callback(core.ScoredMove{
-								PlacedTiles: pt,
-								Score:       b.Score(pt),
-							})
+						if canPlay && !callback(core.ScoredMove{
+							PlacedTiles: pt,
+							Score:       b.Score(pt),
+						}) {
+							return
 						}
 					}
 				}
@@ -1,6 +1,7 @@
 package ai_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -115,7 +116,7 @@ func TestPermuteSpotCheck(t *testing.T) {
 
 func BenchmarkBrute(b *testing.B) {
 	tiles := core.NewConsumableRack(core.MakeTiles(core.MakeWord("bdhrigs"), "xxxxxx "))
-	board := core.NewBoard()
+	board := core.NewBoard(core.ScrabbleLayout)
 	brute := ai.NewBrute(wordDB)
 
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 0, 7, core.Vertical})
@@ -123,6 +124,6 @@ func BenchmarkBrute(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		brute.GenerateMoves(board, tiles, func(core.Turn) bool { return true })
+		brute.GenerateMoves(context.Background(), board, tiles, func(core.Turn) bool { return true })
 	}
 }
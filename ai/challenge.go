@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// Challenger decides whether to challenge the words formed by the last move
+type Challenger interface {
+	Challenge(words []core.PlacedTiles) bool
+}
+
+// WordListChallenger challenges moves which form words missing from its
+// word list. Accuracy is the chance it notices a phony, so a human-like
+// opponent can let some slip by.
+type WordListChallenger struct {
+	wordList core.WordList
+	accuracy float64
+}
+
+var _ Challenger = &WordListChallenger{}
+
+func NewWordListChallenger(wordList core.WordList, accuracy float64) *WordListChallenger {
+	return &WordListChallenger{
+		wordList: wordList,
+		accuracy: accuracy,
+	}
+}
+
+func (w *WordListChallenger) Challenge(words []core.PlacedTiles) bool {
+	for _, word := range words {
+		if !w.wordList.Contains(core.Tiles2Word(word.Word)) {
+			return rand.Float64() < w.accuracy
+		}
+	}
+	return false
+}
+
+// Bluffer wraps another AI, replacing its chosen move with a phony at the
+// given rate. Phonies are made by scrambling the tiles of the chosen move, so
+// they cover the same squares and score about the same.
+type Bluffer struct {
+	ai       AI
+	wordList core.WordList
+	rate     float64
+}
+
+var _ AI = &Bluffer{}
+
+// bluffAttempts is how many scrambles are tried before giving up on a bluff
+const bluffAttempts = 20
+
+func NewBluffer(ai AI, wordList core.WordList, rate float64) *Bluffer {
+	return &Bluffer{
+		ai:       ai,
+		wordList: wordList,
+		rate:     rate,
+	}
+}
+
+func (b *Bluffer) FindMove(ctx context.Context, board *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool) {
+	var best core.Turn
+	b.ai.FindMove(ctx, board, bag, rack, func(t core.Turn) bool {
+		best = t
+		return true
+	})
+	if best == nil {
+		return
+	}
+
+	if move, ok := best.(core.ScoredMove); ok && rand.Float64() < b.rate {
+		if phony, ok := b.scramble(board, move); ok {
+			best = phony
+		}
+	}
+	onMove(best)
+}
+
+// scramble shuffles the tiles of a move until it forms an invalid word
+func (b *Bluffer) scramble(board *core.Board, move core.ScoredMove) (core.ScoredMove, bool) {
+	for i := 0; i < bluffAttempts; i++ {
+		word := make([]core.Tile, len(move.Word))
+		copy(word, move.Word)
+		rand.Shuffle(len(word), func(i, j int) { word[i], word[j] = word[j], word[i] })
+
+		phony := core.PlacedTiles{Word: word, Row: move.Row, Col: move.Col, Direction: move.Direction}
+		err := board.ValidateMoveDetailed(phony, b.wordList)
+		if moveErr, ok := err.(*core.InvalidMoveError); ok && moveErr.Reason == core.InvalidWords {
+			return core.ScoredMove{PlacedTiles: phony, Score: board.Score(phony)}, true
+		}
+	}
+	return core.ScoredMove{}, false
+}
+
+func (b *Bluffer) Name() string {
+	return fmt.Sprintf("%s bluffing %.0f%%", b.ai.Name(), b.rate*100)
+}
@@ -0,0 +1,60 @@
+package ai_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/core/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func tilesOf(word string) []core.Tile {
+	return core.MakeTiles(core.MakeWord(word), strings.Repeat("x", len(word)))
+}
+
+func TestWordListChallenger(t *testing.T) {
+	challenger := ai.NewWordListChallenger(wordDB, 1)
+	assert.True(t, challenger.Challenge([]core.PlacedTiles{{Word: tilesOf("cat")}, {Word: tilesOf("zxq")}}))
+	assert.False(t, challenger.Challenge([]core.PlacedTiles{{Word: tilesOf("cat")}}))
+
+	lenient := ai.NewWordListChallenger(wordDB, 0)
+	assert.False(t, lenient.Challenge([]core.PlacedTiles{{Word: tilesOf("zxq")}}))
+}
+
+func TestBlufferPlaysPhonies(t *testing.T) {
+	smarty := ai.NewSmartyAI(wordDB, wordDB)
+	defer smarty.Kill()
+	bluffer := ai.NewBluffer(smarty, wordDB, 1)
+
+	board := core.NewBoard(core.ScrabbleLayout)
+	rack := core.NewConsumableRack(tilesOf("retains"))
+	var turn core.Turn
+	bluffer.FindMove(context.Background(), board, core.NewConsumableBag(core.EnglishScrabble), rack, func(t core.Turn) bool {
+		turn = t
+		return true
+	})
+
+	move := turn.(core.ScoredMove)
+	err := board.ValidateMoveDetailed(move.PlacedTiles, wordDB)
+	if assert.Error(t, err) {
+		assert.Equal(t, core.InvalidWords, err.(*core.InvalidMoveError).Reason)
+	}
+	assert.NoError(t, rack.ValidatePlay(move.PlacedTiles))
+}
+
+func TestPlayGameWithChallenges(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Plays a full game")
+	}
+	bluffer := func(b *core.Board) *ai.Player {
+		return ai.NewPlayer(ai.NewBluffer(ai.NewSpeedyAI(wordDB, wordGaddag), wordDB, 0.5))
+	}
+	challenger := func(b *core.Board) *ai.Player {
+		return ai.NewPlayer(ai.NewSpeedyAI(wordDB, wordGaddag)).WithChallenger(ai.NewWordListChallenger(wordDB, 1))
+	}
+	record := ai.PlayGameWithRules(wordDB, core.ScrabbleLayout, core.EnglishScrabble, game.DoubleChallenge, bluffer, challenger)
+	assert.NotEmpty(t, record.Moves)
+}
@@ -0,0 +1,22 @@
+package ai
+
+import "github.com/Logiraptor/word-bot/core"
+
+// distinctMoves wraps a GenerateMoves callback so it sees each distinct move
+// once, in canonical form, for generators which can find a move many times
+func distinctMoves(b *core.Board, callback func(core.Turn) bool) func(core.Turn) bool {
+	seen := map[core.MoveKey]bool{}
+	return func(turn core.Turn) bool {
+		m, ok := turn.(core.ScoredMove)
+		if !ok {
+			return callback(turn)
+		}
+		key := b.MoveKey(m.PlacedTiles)
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+		m.PlacedTiles = b.CanonicalMove(m.PlacedTiles)
+		return callback(m)
+	}
+}
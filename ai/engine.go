@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+)
+
+// Engine owns a fixed pool of search workers shared by every AI it makes.
+// It is safe to use from many goroutines at once, and the number of searches
+// running never exceeds the number of workers. Close stops the workers.
+type Engine struct {
+	tasks     chan func()
+	done      chan struct{}
+	closeOnce sync.Once
+	workers   sync.WaitGroup
+}
+
+// NewEngine starts an engine with the given number of workers, or one per
+// CPU if workers is not positive.
+func NewEngine(workers int) *Engine {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	e := &Engine{
+		// tasks is unbuffered, so every task handed off is run even if the
+		// engine is closed right after
+		tasks: make(chan func()),
+		done:  make(chan struct{}),
+	}
+	e.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go e.work()
+	}
+	return e
+}
+
+func (e *Engine) work() {
+	defer e.workers.Done()
+	for {
+		select {
+		case task := <-e.tasks:
+			task()
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// run waits for a free worker to start task. It returns false without
+// running task if ctx is done or the engine is closed first.
+func (e *Engine) run(ctx context.Context, task func()) bool {
+	select {
+	case e.tasks <- task:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-e.done:
+		return false
+	}
+}
+
+// Close stops the workers and waits for running tasks to finish. Searches
+// started after Close find no moves. It is safe to call Close more than once.
+func (e *Engine) Close() {
+	e.closeOnce.Do(func() {
+		close(e.done)
+	})
+	e.workers.Wait()
+}
+
+// NewSmartyAI makes a SmartyAI which searches on the engine's workers.
+func (e *Engine) NewSmartyAI(wordList core.WordList, searchSpace *wordlist.Trie) *SmartyAI {
+	return &SmartyAI{
+		wordList:    wordList,
+		searchSpace: searchSpace,
+		engine:      e,
+	}
+}
+
+// NewSpeedyAI makes a SpeedyAI which searches on the engine's workers.
+func (e *Engine) NewSpeedyAI(wordList core.WordList, searchSpace *wordlist.Gaddag) *SpeedyAI {
+	return &SpeedyAI{
+		wordList:    wordList,
+		searchSpace: searchSpace,
+		engine:      e,
+	}
+}
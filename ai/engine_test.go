@@ -0,0 +1,66 @@
+package ai_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/internal/leaktest"
+)
+
+func countMoves(gen ai.MoveGenerator, rack core.Rack) int {
+	return len(collectMoves(core.NewBoard(core.ScrabbleLayout), rack, gen))
+}
+
+func TestEngineSharedBetweenSearches(t *testing.T) {
+	defer leaktest.Check(t)()
+	rack := core.NewConsumableRack(tiles("retains"))
+	want := countMoves(ai.NewAnchorAI(wordDB, wordDB), rack)
+
+	engine := ai.NewEngine(2)
+	generators := []ai.MoveGenerator{
+		engine.NewSmartyAI(wordDB, wordDB),
+		engine.NewSpeedyAI(wordDB, wordGaddag),
+	}
+	var wg sync.WaitGroup
+	counts := make([]int, 8)
+	for i := range counts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			counts[i] = countMoves(generators[i%len(generators)], rack)
+		}(i)
+	}
+	wg.Wait()
+	engine.Close()
+
+	for _, count := range counts {
+		assert.Equal(t, want, count)
+	}
+}
+
+func TestEngineClose(t *testing.T) {
+	defer leaktest.Check(t)()
+	engine := ai.NewEngine(0)
+	smarty := engine.NewSmartyAI(wordDB, wordDB)
+	smarty.Kill()
+	rack := core.NewConsumableRack(tiles("retains"))
+	assert.NotZero(t, countMoves(smarty, rack), "Kill closed a shared engine")
+
+	engine.Close()
+	engine.Close()
+	assert.Zero(t, countMoves(smarty, rack))
+}
+
+func TestKillStopsWorkers(t *testing.T) {
+	defer leaktest.Check(t)()
+	smarty := ai.NewSmartyAI(wordDB, wordDB)
+	speedy := ai.NewSpeedyAI(wordDB, wordGaddag)
+	rack := core.NewConsumableRack(tiles("retains"))
+	assert.Equal(t, countMoves(smarty, rack), countMoves(speedy, rack))
+	smarty.Kill()
+	speedy.Kill()
+}
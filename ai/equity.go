@@ -0,0 +1,201 @@
+package ai
+
+import (
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// LeaveHeuristics estimates what a leave is worth from rules of thumb: each
+// tile kept has a value, and leaves are penalized for an imbalance of vowels
+// and consonants, for duplicate letters and for a q without a u.
+type LeaveHeuristics struct {
+	// Tiles holds the value of keeping each letter, indexed by Letter.
+	// Letters past its end are worth nothing.
+	Tiles []float64
+	Blank float64
+	// Vowels holds the letters counted as vowels, every other letter is a
+	// consonant. Blanks are neither.
+	Vowels core.LetterSet
+	// BalancePenalty is charged for each vowel or consonant by which one
+	// outnumbers the other, beyond the first
+	BalancePenalty float64
+	// DuplicatePenalty is charged for each extra copy of a letter
+	DuplicatePenalty float64
+	// QWithoutU is charged for keeping a q without a u or a blank
+	QWithoutU float64
+}
+
+var letterQ, letterU = core.Rune2Letter('q'), core.Rune2Letter('u')
+
+func letterSet(letters string) core.LetterSet {
+	var set core.LetterSet
+	for _, r := range letters {
+		set |= 1 << uint(core.Rune2Letter(r))
+	}
+	return set
+}
+
+// DefaultLeaveHeuristics are tuned for English Scrabble
+var DefaultLeaveHeuristics = LeaveHeuristics{
+	Tiles: []float64{
+		// a    b     c    d    e    f     g     h    i     j     k     l    m
+		1.0, -2.0, 0.5, 0.5, 4.0, -2.0, -2.5, 1.0, -0.5, -1.5, -1.0, 0.5, 0.5,
+		// n  o     p     q     r    s    t    u     v     w     x    y     z
+		0.5, -1.0, -0.5, -2.0, 1.5, 8.0, 0.5, -3.5, -5.5, -3.5, 3.5, -0.5, 5.0,
+	},
+	Blank:            25,
+	Vowels:           letterSet("aeiou"),
+	BalancePenalty:   2.5,
+	DuplicatePenalty: 3,
+	QWithoutU:        5.5,
+}
+
+// Value estimates what keeping leave is worth
+func (h LeaveHeuristics) Value(leave []core.Tile) float64 {
+	var value float64
+	var counts [core.MaxLetters]int
+	vowels, consonants, blanks := 0, 0, 0
+	for _, t := range leave {
+		if t.IsBlank() {
+			value += h.Blank
+			blanks++
+			continue
+		}
+		l := t.ToLetter()
+		if int(l) < len(h.Tiles) {
+			value += h.Tiles[l]
+		}
+		counts[l]++
+		if counts[l] > 1 {
+			value -= h.DuplicatePenalty
+		}
+		if h.Vowels.Contains(l) {
+			vowels++
+		} else {
+			consonants++
+		}
+	}
+
+	imbalance := vowels - consonants
+	if imbalance < 0 {
+		imbalance = -imbalance
+	}
+	if imbalance > 1 {
+		value -= h.BalancePenalty * float64(imbalance-1)
+	}
+	if counts[letterQ] > 0 && counts[letterU] == 0 && blanks == 0 {
+		value -= h.QWithoutU
+	}
+	return value
+}
+
+// Equity values a move by its score, plus what its leave is worth, less a
+// penalty for each triple word square it opens. Leaves are looked up in
+// Leaves, and those missing from it are valued by Heuristics.
+type Equity struct {
+	Leaves     LeaveValues
+	Heuristics LeaveHeuristics
+	// TripleWordPenalty is charged for each lane a move opens to an empty
+	// triple word square, see OpenedLanes
+	TripleWordPenalty float64
+}
+
+// NewEquity values leaves with the given table, which may be nil, and the
+// default heuristics
+func NewEquity(leaves LeaveValues) *Equity {
+	return &Equity{
+		Leaves:            leaves,
+		Heuristics:        DefaultLeaveHeuristics,
+		TripleWordPenalty: 4,
+	}
+}
+
+var (
+	_ ComponentEvaluator = &Equity{}
+	_ ExchangeEvaluator  = &Equity{}
+)
+
+func (e *Equity) Evaluate(b *core.Board, rack core.Rack, move core.ScoredMove) float64 {
+	var value float64
+	for _, c := range e.Components(b, rack, move) {
+		value += c.Value
+	}
+	return value
+}
+
+// Components splits a move's equity into its score, leave and position
+func (e *Equity) Components(b *core.Board, rack core.Rack, move core.ScoredMove) []Component {
+	leave, _ := rack.Play(move.Word)
+	return []Component{
+		{Name: "score", Value: float64(move.Score)},
+		{Name: "leave", Value: e.LeaveValue(leave.Rack)},
+		{Name: "position", Value: -e.TripleWordPenalty * float64(OpenedLanes(b, move.PlacedTiles))},
+	}
+}
+
+// EvaluateExchange values an exchange by the tiles it keeps
+func (e *Equity) EvaluateExchange(b *core.Board, rack core.Rack, exchange core.Exchange) float64 {
+	leave, _ := rack.Play(exchange.Tiles)
+	return e.LeaveValue(leave.Rack)
+}
+
+// LeaveValue finds what keeping leave is worth
+func (e *Equity) LeaveValue(leave []core.Tile) float64 {
+	if value, ok := e.Leaves[LeaveKey(leave)]; ok {
+		return value
+	}
+	return e.Heuristics.Value(leave)
+}
+
+// OpenedLanes counts the lanes to empty triple word squares that move would
+// open without changing the board. A lane runs from a triple word square
+// along its row or column to the nearest tile, if that tile is close enough
+// for a rack of tiles to reach the square from it.
+func OpenedLanes(b *core.Board, move core.PlacedTiles) int {
+	var placed []int
+	dRow, dCol := move.Direction.Offsets()
+	row, col := move.Row, move.Col
+	for i := 0; i < len(move.Word) && !b.OutOfBounds(row, col); row, col = row+dRow, col+dCol {
+		if !b.HasTile(row, col) {
+			placed = append(placed, row*b.Layout.Cols+col)
+			i++
+		}
+	}
+	isPlaced := func(row, col int) bool {
+		for _, square := range placed {
+			if square == row*b.Layout.Cols+col {
+				return true
+			}
+		}
+		return false
+	}
+	hasTile := func(row, col int) bool {
+		return b.HasTile(row, col) || isPlaced(row, col)
+	}
+	reaches := func(row, col, dRow, dCol int, occupied func(row, col int) bool) bool {
+		for i := 0; i < b.TileSet.RackSize; i++ {
+			row, col = row+dRow, col+dCol
+			if b.OutOfBounds(row, col) {
+				return false
+			}
+			if occupied(row, col) {
+				return true
+			}
+		}
+		return false
+	}
+
+	opened := 0
+	for row := 0; row < b.Layout.Rows; row++ {
+		for col := 0; col < b.Layout.Cols; col++ {
+			if b.BonusAt(row, col) != core.TripleWord || hasTile(row, col) {
+				continue
+			}
+			for _, d := range [][2]int{{0, 1}, {0, -1}, {1, 0}, {-1, 0}} {
+				if reaches(row, col, d[0], d[1], hasTile) && !reaches(row, col, d[0], d[1], b.HasTile) {
+					opened++
+				}
+			}
+		}
+	}
+	return opened
+}
@@ -0,0 +1,120 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+)
+
+func TestLeaveHeuristics(t *testing.T) {
+	h := ai.DefaultLeaveHeuristics
+	assert.Equal(t, 0.0, h.Value(nil))
+	assert.Equal(t, 8.0, h.Value(tiles("s")))
+	assert.Equal(t, 25.0, h.Value(tiles("A")))
+	assert.Equal(t, 5.0, h.Value(tiles("eerr")), "duplicates are penalized")
+	assert.Equal(t, 5.0, h.Value(tiles("rst")), "three consonants are unbalanced")
+	assert.Equal(t, -10.0, h.Value(tiles("aeiou")), "five vowels are unbalanced")
+	assert.Equal(t, -7.5, h.Value(tiles("q")))
+	assert.Equal(t, -5.5, h.Value(tiles("qu")), "a u rescues the q")
+	assert.Equal(t, 23.0, h.Value(tiles("qA")), "a blank rescues the q")
+}
+
+func TestEquityLeaves(t *testing.T) {
+	equity := ai.NewEquity(ai.LeaveValues{"es": 20})
+	assert.Equal(t, 20.0, equity.LeaveValue(tiles("se")))
+	assert.Equal(t, 8.0, equity.LeaveValue(tiles("s")), "leaves missing from the table use the heuristics")
+}
+
+func TestOpenedLanes(t *testing.T) {
+	b := core.NewBoard(core.ScrabbleLayout)
+	// the center row and column lead to four triple word squares
+	assert.Equal(t, 4, ai.OpenedLanes(b, core.PlacedTiles{Word: tiles("cat"), Row: 7, Col: 6, Direction: core.Horizontal}))
+	assert.Equal(t, 1, ai.OpenedLanes(b, core.PlacedTiles{Word: tiles("cat"), Row: 7, Col: 8, Direction: core.Horizontal}))
+	assert.Equal(t, 4, ai.OpenedLanes(b, core.PlacedTiles{Word: tiles("cat"), Row: 5, Col: 7, Direction: core.Vertical}))
+
+	b.PlaceTiles(core.PlacedTiles{Word: tiles("cat"), Row: 7, Col: 6, Direction: core.Horizontal})
+	assert.Equal(t, 0, ai.OpenedLanes(b, core.PlacedTiles{Word: tiles("s"), Row: 7, Col: 9, Direction: core.Horizontal}), "the lanes were already open")
+	assert.Equal(t, 2, ai.OpenedLanes(b, core.PlacedTiles{Word: tiles("abcdefg"), Row: 8, Col: 8, Direction: core.Vertical}), "the bottom row was opened")
+}
+
+func TestEquityChooser(t *testing.T) {
+	board := core.NewBoard(core.ScrabbleLayout)
+	rack := core.NewConsumableRack(tiles("aestvwz"))
+	equity := ai.NewEquity(nil)
+	chooser := ai.NewMoveChooser("Equity", ai.NewAnchorAI(wordDB, wordDB), equity)
+
+	var chosen core.Turn
+	chooser.FindMove(context.Background(), board, core.NewConsumableBag(core.EnglishScrabble), rack, func(turn core.Turn) bool {
+		chosen = turn
+		return true
+	})
+	top := ai.TopMoves(context.Background(), ai.NewAnchorAI(wordDB, wordDB), equity, board, rack, 1)
+	if move, ok := chosen.(core.ScoredMove); assert.True(t, ok) && assert.Len(t, top, 1) {
+		assert.Equal(t, top[0].Value, equity.Evaluate(board, rack, move))
+		var sum float64
+		for _, c := range top[0].Components {
+			sum += c.Value
+		}
+		assert.Equal(t, top[0].Value, sum)
+	}
+}
+
+func TestEquityChooserExchanges(t *testing.T) {
+	board := core.NewBoard(core.ScrabbleLayout)
+	bag := core.NewConsumableBag(core.EnglishScrabble)
+	// no word can be made without a vowel
+	rack := core.NewConsumableRack(tiles("vvwwqqz"))
+	equity := ai.NewEquity(nil)
+
+	var chosen core.Turn
+	ai.NewMoveChooser("Equity", ai.NewAnchorAI(wordDB, wordDB), equity).FindMove(context.Background(), board, bag, rack, func(turn core.Turn) bool {
+		chosen = turn
+		return true
+	})
+	if exchange, ok := chosen.(core.Exchange); assert.True(t, ok, "chose %v", chosen) {
+		best := equity.EvaluateExchange(board, rack, exchange)
+		ai.GenerateExchanges(bag, rack, func(turn core.Turn) bool {
+			assert.True(t, best >= equity.EvaluateExchange(board, rack, turn.(core.Exchange)))
+			return true
+		})
+	}
+
+	chosen = nil
+	ai.NewMoveChooser("Score", ai.NewAnchorAI(wordDB, wordDB), ai.ScoreEvaluator{}).FindMove(context.Background(), board, bag, rack, func(turn core.Turn) bool {
+		chosen = turn
+		return true
+	})
+	assert.Nil(t, chosen, "exchanges can't be valued by score")
+}
+
+func TestEquityChooserNegativeEquity(t *testing.T) {
+	board := core.NewBoard(core.ScrabbleLayout)
+	rack := core.NewConsumableRack(tiles("aiiuuvv"))
+	bag := core.NewConsumableBag(core.EnglishScrabble)
+	// too few tiles are left to exchange, so a move must be played
+	bag = bag.ConsumeTiles(bag.Remaining()[:95])
+	equity := ai.NewEquity(nil)
+
+	top := ai.TopMoves(context.Background(), ai.NewAnchorAI(wordDB, wordDB), equity, board, rack, 1)
+	if !assert.Len(t, top, 1) || !assert.True(t, top[0].Value < 0, "every move should have negative equity") {
+		return
+	}
+	// each turn offered must improve on the last, ending with the best one
+	var values []float64
+	ai.NewMoveChooser("Equity", ai.NewAnchorAI(wordDB, wordDB), equity).FindMove(context.Background(), board, bag, rack, func(turn core.Turn) bool {
+		if move, ok := turn.(core.ScoredMove); assert.True(t, ok) {
+			values = append(values, equity.Evaluate(board, rack, move))
+		}
+		return true
+	})
+	if assert.NotEmpty(t, values) {
+		for i := 1; i < len(values); i++ {
+			assert.True(t, values[i] > values[i-1], "turn %d is no better than the one before", i)
+		}
+		assert.Equal(t, top[0].Value, values[len(values)-1])
+	}
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"sort"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// GenerateExchanges calls onMove with every distinct exchange of one or more
+// tiles from the rack, stopping early if onMove returns false. Nothing is
+// generated when the bag is too small to exchange.
+func GenerateExchanges(bag core.Bag, rack core.Rack, onMove func(core.Turn) bool) {
+	if !bag.CanExchange() || len(rack.Rack) == 0 {
+		return
+	}
+
+	tiles := make([]core.Tile, len(rack.Rack))
+	copy(tiles, rack.Rack)
+	sort.Slice(tiles, func(i, j int) bool { return tiles[i] < tiles[j] })
+
+	// group duplicate tiles so each combination is generated once
+	var distinct []core.Tile
+	var counts []int
+	for i, t := range tiles {
+		if i > 0 && t == tiles[i-1] {
+			counts[len(counts)-1]++
+			continue
+		}
+		distinct = append(distinct, t)
+		counts = append(counts, 1)
+	}
+
+	chosen := make([]core.Tile, 0, len(tiles))
+	var choose func(i int) bool
+	choose = func(i int) bool {
+		if i == len(distinct) {
+			if len(chosen) == 0 {
+				return true
+			}
+			exchange := make([]core.Tile, len(chosen))
+			copy(exchange, chosen)
+			return onMove(core.Exchange{Tiles: exchange})
+		}
+		n := len(chosen)
+		for k := 0; k <= counts[i]; k++ {
+			if k > 0 {
+				chosen = append(chosen, distinct[i])
+			}
+			if !choose(i + 1) {
+				return false
+			}
+		}
+		chosen = chosen[:n]
+		return true
+	}
+	choose(0)
+}
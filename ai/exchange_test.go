@@ -0,0 +1,28 @@
+package ai_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateExchanges(bag core.Bag, rack string) []string {
+	var output []string
+	ai.GenerateExchanges(bag, core.NewConsumableRack(core.MakeTiles(core.MakeWord(rack), strings.Repeat("x", len(rack)))), func(t core.Turn) bool {
+		output = append(output, core.Tiles2String(t.(core.Exchange).Tiles))
+		return true
+	})
+	return output
+}
+
+func TestGenerateExchanges(t *testing.T) {
+	bag := core.NewConsumableBag(core.EnglishScrabble)
+	assert.ElementsMatch(t, []string{"a", "aa", "b", "ab", "aab"}, generateExchanges(bag, "aba"))
+	assert.Len(t, generateExchanges(bag, "abcdefg"), 127)
+
+	bag = bag.ConsumeTiles(bag.Remaining()[:94])
+	assert.Empty(t, generateExchanges(bag, "abcdefg"))
+}
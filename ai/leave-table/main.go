@@ -0,0 +1,43 @@
+// Command leave-table writes the value of every leave of up to -max tiles,
+// estimated with ai.DefaultLeaveHeuristics, in the format read by
+// ai.LoadLeaveValuesFile. Point WORD_BOT_LEAVES at the file to rank moves by
+// equity with it.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+)
+
+func main() {
+	tileSetName := flag.String("tiles", core.EnglishScrabble.Name, "tile set the leaves are drawn from")
+	max := flag.Int("max", 6, "largest leave to value")
+	out := flag.String("out", "leaves.tsv", "file to write the table to")
+	flag.Parse()
+
+	tiles, ok := core.LookupTileSet(*tileSetName)
+	if !ok {
+		log.Fatalf("unknown tile set %q", *tileSetName)
+	}
+
+	start := time.Now()
+	values := ai.PrecomputeLeaves(tiles, *max, ai.DefaultLeaveHeuristics.Value)
+	log.Printf("valued %d leaves in %s", len(values), time.Since(start))
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ai.WriteLeaveValues(f, values)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,91 @@
+// Command leave-trainer fits leave values by self-play and saves them as the
+// leave weights in a database, where ai.NewLeaveWeighter loads them. Each
+// round plays -games games between two equity players, which value leaves
+// with the fit from the rounds before, then fits every leave seen so far.
+package main
+
+import (
+	"flag"
+	"log"
+	"runtime"
+	"sync"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/persist"
+	"github.com/Logiraptor/word-bot/wordlist"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func main() {
+	dbPath := flag.String("db", "smart-results.db", "sqlite database to save the leave weights in")
+	games := flag.Int("games", 1000, "games to play each round")
+	rounds := flag.Int("rounds", 1, "rounds of games to play")
+	prior := flag.Float64("prior", 10, "records of an average outcome each leave starts with")
+	minCount := flag.Int("min", 5, "times a leave must be seen to be saved")
+	workers := flag.Int("workers", runtime.NumCPU(), "games to play at once")
+	flag.Parse()
+
+	db, err := persist.NewDB(*dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	wordDB, err := wordlist.Default.Trie()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	trainer := ai.NewLeaveTrainer()
+	var values ai.LeaveValues
+	for round := 0; round < *rounds; round++ {
+		equity := ai.NewEquity(values)
+		player := func(name string) func(*core.Board) *ai.Player {
+			chooser := ai.NewMoveChooser(name, ai.NewAnchorAI(wordDB, wordDB), equity)
+			return func(*core.Board) *ai.Player {
+				return ai.NewPlayer(chooser)
+			}
+		}
+		p1, p2 := player("Trainer 1"), player("Trainer 2")
+
+		results := make(chan persist.Game)
+		jobs := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < *workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range jobs {
+					results <- ai.PlayGame(wordDB, p1, p2)
+				}
+			}()
+		}
+		go func() {
+			for i := 0; i < *games; i++ {
+				jobs <- struct{}{}
+			}
+			close(jobs)
+			wg.Wait()
+			close(results)
+		}()
+
+		played := 0
+		for g := range results {
+			if err := trainer.RecordGame(g); err != nil {
+				log.Print(err)
+				continue
+			}
+			played++
+			if played%100 == 0 {
+				log.Printf("round %d: played %d / %d games", round+1, played, *games)
+			}
+		}
+		values = trainer.Fit(*prior, *minCount)
+		log.Printf("round %d: fit %d leaves", round+1, len(values))
+	}
+
+	err = db.SaveLeaveWeights(values.Weights())
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("saved %d leave weights to %s", len(values), *dbPath)
+}
@@ -0,0 +1,120 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/persist"
+)
+
+// LeaveTrainer fits leave values to the outcomes of games. Every leave a
+// player keeps is credited with the points that player finished the game
+// ahead or behind by, and a leave's value is how far its average outcome is
+// from the average of every leave.
+type LeaveTrainer struct {
+	sums   map[string]float64
+	counts map[string]int
+	total  float64
+	n      int
+}
+
+func NewLeaveTrainer() *LeaveTrainer {
+	return &LeaveTrainer{
+		sums:   map[string]float64{},
+		counts: map[string]int{},
+	}
+}
+
+// Record notes that a player kept leave and finished the game diff points
+// ahead
+func (t *LeaveTrainer) Record(leave []core.Tile, diff float64) {
+	key := LeaveKey(leave)
+	t.sums[key] += diff
+	t.counts[key]++
+	t.total += diff
+	t.n++
+}
+
+// RecordGame records the leave of every play and exchange in a game between
+// two players with different names
+func (t *LeaveTrainer) RecordGame(g persist.Game) error {
+	scores := map[string]core.Score{}
+	var players []string
+	for _, m := range g.Moves {
+		if _, ok := scores[m.Player]; !ok {
+			players = append(players, m.Player)
+		}
+		scores[m.Player] += m.Score
+	}
+	if len(players) != 2 {
+		return fmt.Errorf("game %d has %d players, expected 2", g.ID, len(players))
+	}
+
+	for _, m := range g.Moves {
+		if m.Kind != persist.KindPlay && m.Kind != persist.KindExchange && m.Kind != "" {
+			continue
+		}
+		opponent := players[0]
+		if m.Player == opponent {
+			opponent = players[1]
+		}
+		leave, err := g.ParseTiles(m.Leave)
+		if err != nil {
+			return fmt.Errorf("game %d move %d: %s", g.ID, m.ID, err)
+		}
+		t.Record(leave, float64(scores[m.Player]-scores[opponent]))
+	}
+	return nil
+}
+
+// Fit values each leave recorded at least minCount times. Values are shrunk
+// toward zero as if each leave had prior more records of an average outcome,
+// so rarely seen leaves aren't valued by a few lucky games.
+func (t *LeaveTrainer) Fit(prior float64, minCount int) LeaveValues {
+	values := LeaveValues{}
+	if t.n == 0 {
+		return values
+	}
+	mean := t.total / float64(t.n)
+	for key, sum := range t.sums {
+		count := t.counts[key]
+		if count < minCount {
+			continue
+		}
+		values[key] = (sum - mean*float64(count)) / (float64(count) + prior)
+	}
+	return values
+}
+
+// Weights converts values to rows for persist.DB.SaveLeaveWeights, sorted by
+// leave. Rows hold values in the units LeaveWeighter reads.
+func (v LeaveValues) Weights() []persist.LeaveWeight {
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	weights := make([]persist.LeaveWeight, len(keys))
+	for i, key := range keys {
+		weights[i] = persist.LeaveWeight{
+			Leave:  core.Tiles2String(leaveTiles(key)),
+			Weight: v[key] / leaveWeightScale,
+		}
+	}
+	return weights
+}
+
+// leaveTiles reads the tiles of a LeaveKey
+func leaveTiles(key string) []core.Tile {
+	tiles := make([]core.Tile, 0, len(key))
+	for _, r := range key {
+		if r == '?' {
+			tiles = append(tiles, core.Letter(0).ToTile(true))
+		} else {
+			tiles = append(tiles, core.Rune2Letter(r).ToTile(false))
+		}
+	}
+	return tiles
+}
@@ -0,0 +1,67 @@
+package ai_test
+
+import (
+	"testing"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/persist"
+)
+
+func TestLeaveTrainerFit(t *testing.T) {
+	trainer := ai.NewLeaveTrainer()
+	trainer.Record(tiles("sA"), 30)
+	trainer.Record(tiles("As"), 10)
+	trainer.Record(tiles("qv"), -40)
+	trainer.Record(tiles("e"), 0)
+
+	// the average outcome is 0
+	assert.Equal(t, ai.LeaveValues{"s?": 20, "qv": -40, "e": 0}, trainer.Fit(0, 1))
+	assert.Equal(t, ai.LeaveValues{"s?": 10, "qv": -40.0 / 3, "e": 0}, trainer.Fit(2, 1), "the prior shrinks values")
+	assert.Equal(t, ai.LeaveValues{"s?": 20}, trainer.Fit(0, 2), "rare leaves are left out")
+	assert.Empty(t, ai.NewLeaveTrainer().Fit(0, 1))
+}
+
+func TestLeaveTrainerRecordGame(t *testing.T) {
+	trainer := ai.NewLeaveTrainer()
+	assert.NoError(t, trainer.RecordGame(persist.Game{Moves: []persist.Move{
+		{Kind: persist.KindPlay, Leave: "sAe", Player: "a", Score: 30},
+		{Kind: persist.KindExchange, Leave: "qv", Player: "b"},
+		{Kind: persist.KindPhony, Leave: "zz", Player: "a"},
+		{Kind: persist.KindPlay, Leave: "se", Player: "a"},
+		{Kind: persist.KindPlay, Leave: "", Player: "b", Score: 12},
+		{Kind: persist.KindRackAdjustment, Leave: "es", Player: "a", Score: -2},
+		{Kind: persist.KindRackAdjustment, Player: "b", Score: 2},
+	}}))
+	// a finished 28 to 14
+	assert.Equal(t, ai.LeaveValues{"es?": 14, "es": 14, "qv": -14, "": -14}, trainer.Fit(0, 1))
+
+	assert.Error(t, trainer.RecordGame(persist.Game{Moves: []persist.Move{{Kind: persist.KindPlay, Player: "a"}}}))
+}
+
+func TestLeaveWeighterLoadsFit(t *testing.T) {
+	db, err := persist.NewDB(":memory:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.DB.Close()
+
+	values := ai.LeaveValues{"es?": 14, "qv": -14}
+	assert.Equal(t, []persist.LeaveWeight{{Leave: "esA", Weight: 1.4}, {Leave: "qv", Weight: -1.4}}, values.Weights(), "weights are saved in tenths of a point")
+	assert.NoError(t, db.SaveLeaveWeights(values.Weights()))
+
+	weighter := ai.NewLeaveWeighter(db)
+	rack := core.NewConsumableRack(tiles("vcaqt"))
+	move := core.ScoredMove{PlacedTiles: core.PlacedTiles{Word: tiles("cat")}, Score: 5}
+	assert.Equal(t, -9.0, weighter.Evaluate(nil, rack, move))
+	rack = core.NewConsumableRack(tiles("catxs"))
+	assert.Equal(t, 16.0, weighter.Evaluate(nil, rack, move), "leaves without weights are worth 11")
+
+	// rows saved by update-weights.sql are win rates
+	assert.NoError(t, db.SaveLeaveWeights([]persist.LeaveWeight{{Leave: "qv", Weight: 0.5}}))
+	rack = core.NewConsumableRack(tiles("vcaqt"))
+	assert.Equal(t, 10.0, ai.NewLeaveWeighter(db).Evaluate(nil, rack, move))
+}
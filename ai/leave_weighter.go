@@ -3,27 +3,58 @@ package ai
 import "github.com/Logiraptor/word-bot/core"
 import "github.com/Logiraptor/word-bot/persist"
 
+// LeaveWeighter values a move by its score plus the weight saved for its
+// leave, scaled by leaveWeightScale. Leaves without a weight are worth
+// unweightedLeave points.
 type LeaveWeighter struct {
-	weights map[string]float64
+	weights LeaveValues
 }
 
+// Saved leave weights are tenths of a point, like the win rates
+// ai-showdown/update-weights.sql saves
+const (
+	leaveWeightScale = 10
+	unweightedLeave  = 11
+)
+
 func NewLeaveWeighter(db *persist.DB) *LeaveWeighter {
 	records, _ := db.LoadLeaveWeights()
-	weights := make(map[string]float64)
+	weights := make(LeaveValues)
 	for _, r := range records {
-		weights[r.Leave] = r.Weight
+		// leaves are saved in any order, written by core.Tiles2String
+		weights[LeaveKey(core.String2Tiles(r.Leave))] = r.Weight
 	}
 	return &LeaveWeighter{
 		weights: weights,
 	}
 }
 
-var _ MoveEvaluator = &LeaveWeighter{}
+var (
+	_ ComponentEvaluator = &LeaveWeighter{}
+	_ ExchangeEvaluator  = &LeaveWeighter{}
+)
 
 func (l *LeaveWeighter) Evaluate(b *core.Board, rack core.Rack, move core.ScoredMove) float64 {
-	leave, _ := rack.Play(move.Word)
-	if leaveScore, ok := l.weights[core.Tiles2String(leave.Rack)]; ok {
-		return float64(move.Score) + leaveScore*10
+	return float64(move.Score) + l.leaveValue(rack, move.Word)
+}
+
+// Components splits a move's value into its score and the value of its leave
+func (l *LeaveWeighter) Components(b *core.Board, rack core.Rack, move core.ScoredMove) []Component {
+	return []Component{
+		{Name: "score", Value: float64(move.Score)},
+		{Name: "leave", Value: l.leaveValue(rack, move.Word)},
+	}
+}
+
+// EvaluateExchange values an exchange by the tiles it keeps
+func (l *LeaveWeighter) EvaluateExchange(b *core.Board, rack core.Rack, exchange core.Exchange) float64 {
+	return l.leaveValue(rack, exchange.Tiles)
+}
+
+func (l *LeaveWeighter) leaveValue(rack core.Rack, played []core.Tile) float64 {
+	leave, _ := rack.Play(played)
+	if weight, ok := l.weights[LeaveKey(leave.Rack)]; ok {
+		return weight * leaveWeightScale
 	}
-	return float64(move.Score) + 11
+	return unweightedLeave
 }
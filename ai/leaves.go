@@ -0,0 +1,142 @@
+package ai
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// LeaveValues values the tiles kept on a rack after a move, keyed by
+// LeaveKey
+type LeaveValues map[string]float64
+
+// LeaveKey writes tiles in a standard order, so every arrangement of a leave
+// has the same key. Letters are written as by Tile.String, and blanks as ?
+// after them.
+func LeaveKey(tiles []core.Tile) string {
+	sorted := make([]core.Tile, len(tiles))
+	copy(sorted, tiles)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].IsBlank() != sorted[j].IsBlank() {
+			return !sorted[i].IsBlank()
+		}
+		return sorted[i].ToLetter() < sorted[j].ToLetter()
+	})
+	var sb strings.Builder
+	for _, t := range sorted {
+		if t.IsBlank() {
+			sb.WriteByte('?')
+		} else {
+			sb.WriteRune(t.ToRune())
+		}
+	}
+	return sb.String()
+}
+
+// EachLeave calls f with every distinct leave of up to maxSize tiles that
+// can be drawn from the tile set, blanks included. f must not keep leave.
+func EachLeave(tiles *core.TileSet, maxSize int, f func(leave []core.Tile)) {
+	var kinds []core.Tile
+	var counts []int
+	for l, n := range tiles.Counts {
+		if n > 0 {
+			kinds = append(kinds, core.Letter(l).ToTile(false))
+			counts = append(counts, n)
+		}
+	}
+	if tiles.Blanks > 0 {
+		kinds = append(kinds, core.Letter(0).ToTile(true))
+		counts = append(counts, tiles.Blanks)
+	}
+
+	leave := make([]core.Tile, 0, maxSize)
+	var choose func(kind int)
+	choose = func(kind int) {
+		f(leave)
+		for k := kind; k < len(kinds); k++ {
+			if len(leave) == maxSize {
+				return
+			}
+			taken := 0
+			for i := len(leave) - 1; i >= 0 && leave[i] == kinds[k]; i-- {
+				taken++
+			}
+			if taken == counts[k] {
+				continue
+			}
+			leave = append(leave, kinds[k])
+			choose(k)
+			leave = leave[:len(leave)-1]
+		}
+	}
+	choose(0)
+}
+
+// PrecomputeLeaves values every leave of up to maxSize tiles from the tile
+// set
+func PrecomputeLeaves(tiles *core.TileSet, maxSize int, value func(leave []core.Tile) float64) LeaveValues {
+	values := LeaveValues{}
+	EachLeave(tiles, maxSize, func(leave []core.Tile) {
+		values[LeaveKey(leave)] = value(leave)
+	})
+	return values
+}
+
+// LoadLeaveValuesFile reads leave values written by WriteLeaveValues
+func LoadLeaveValuesFile(filename string) (LeaveValues, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadLeaveValues(f)
+}
+
+// LoadLeaveValues reads lines of a leave key and its value separated by a
+// tab. Blank lines are ignored.
+func LoadLeaveValues(r io.Reader) (LeaveValues, error) {
+	values := LeaveValues{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a leave and a value separated by a tab", line)
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: bad value for %q: %s", line, fields[0], err)
+		}
+		values[fields[0]] = value
+	}
+	return values, scanner.Err()
+}
+
+// WriteLeaveValues writes values, sorted by leave, for LoadLeaveValues
+func WriteLeaveValues(w io.Writer, values LeaveValues) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buf := bufio.NewWriter(w)
+	for _, key := range keys {
+		_, err := fmt.Fprintf(buf, "%s\t%s\n", key, strconv.FormatFloat(values[key], 'f', -1, 64))
+		if err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
@@ -0,0 +1,48 @@
+package ai_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+)
+
+func TestLeaveKey(t *testing.T) {
+	assert.Equal(t, "aerst", ai.LeaveKey(tiles("stare")))
+	assert.Equal(t, "eq??", ai.LeaveKey(tiles("qAeA")))
+	assert.Equal(t, "", ai.LeaveKey(nil))
+}
+
+func TestEachLeave(t *testing.T) {
+	// the empty leave, 27 singles, 351 pairs of different tiles and 22 pairs
+	// of tiles the bag holds two of
+	calls := 0
+	values := ai.PrecomputeLeaves(core.EnglishScrabble, 2, func(leave []core.Tile) float64 {
+		calls++
+		return float64(len(leave))
+	})
+	assert.Equal(t, 401, calls)
+	assert.Len(t, values, calls, "a leave was repeated")
+	assert.Equal(t, 2.0, values["??"])
+	assert.NotContains(t, values, "qq")
+}
+
+func TestLeaveValuesRoundTrip(t *testing.T) {
+	values := ai.LeaveValues{"": 0, "?": 25.5, "qu": -5, "ers": 12.25}
+	var buf bytes.Buffer
+	assert.NoError(t, ai.WriteLeaveValues(&buf, values))
+	assert.Equal(t, "\t0\n?\t25.5\ners\t12.25\nqu\t-5\n", buf.String())
+
+	loaded, err := ai.LoadLeaveValues(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, values, loaded)
+
+	_, err = ai.LoadLeaveValues(strings.NewReader("qu\tlots\n"))
+	assert.EqualError(t, err, `line 1: bad value for "qu": strconv.ParseFloat: parsing "lots": invalid syntax`)
+	_, err = ai.LoadLeaveValues(strings.NewReader("\nqu -5\n"))
+	assert.EqualError(t, err, "line 2: expected a leave and a value separated by a tab")
+}
@@ -1,6 +1,11 @@
 package ai
 
-import "github.com/Logiraptor/word-bot/core"
+import (
+	"context"
+	"math"
+
+	"github.com/Logiraptor/word-bot/core"
+)
 
 type MoveChooser struct {
 	name      string
@@ -18,17 +23,37 @@ func NewMoveChooser(name string, gen MoveGenerator, eval MoveEvaluator) *MoveCho
 
 var _ AI = &MoveChooser{}
 
-func (m *MoveChooser) FindMove(b *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool) {
-	var bestScore float64
-	m.generator.GenerateMoves(b, rack, func(t core.Turn) bool {
-		if sm, ok := t.(core.ScoredMove); ok {
-			score := m.evaluator.Evaluate(b, rack, sm)
-			if score > bestScore {
-				bestScore = score
-				return onMove(sm)
-			}
+// FindMove evaluates every generated move, calling onMove each time a better
+// turn is found. Allowed exchanges are considered too if the evaluator is an
+// ExchangeEvaluator.
+func (m *MoveChooser) FindMove(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool) {
+	// values may be negative, so the first turn is always better
+	bestScore := math.Inf(-1)
+	keepGoing := true
+	m.generator.GenerateMoves(ctx, b, rack, func(t core.Turn) bool {
+		sm, ok := t.(core.ScoredMove)
+		if !ok {
+			return true
+		}
+		if score := m.evaluator.Evaluate(b, rack, sm); score > bestScore {
+			bestScore = score
+			keepGoing = onMove(sm)
+			return keepGoing
 		}
-		if bestScore == 0 {
+		return true
+	})
+	if !keepGoing || ctx.Err() != nil {
+		return
+	}
+
+	exchanges, ok := m.evaluator.(ExchangeEvaluator)
+	if !ok {
+		return
+	}
+	GenerateExchanges(bag, rack, func(t core.Turn) bool {
+		score := exchanges.EvaluateExchange(b, rack, t.(core.Exchange))
+		if score > bestScore {
+			bestScore = score
 			return onMove(t)
 		}
 		return true
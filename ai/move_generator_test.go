@@ -1,6 +1,8 @@
 package ai_test
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,9 +55,9 @@ func TestBruteMoveGen(t *testing.T) {
 
 func collectMoves(board *core.Board, rack core.Rack, moveGen ai.MoveGenerator) []core.PlacedTiles {
 	output := []core.PlacedTiles{}
-	moveGen.GenerateMoves(board, rack, func(t core.Turn) bool {
+	moveGen.GenerateMoves(context.Background(), board, rack, func(t core.Turn) bool {
 		if m, ok := t.(core.ScoredMove); ok {
-			output = append(output, board.NormalizeMove(m.PlacedTiles))
+			output = append(output, m.PlacedTiles)
 		}
 		return true
 	})
@@ -66,13 +68,77 @@ func MoveGeneratorContract(t *testing.T, makeMoveGenerator MoveGenConstructor) {
 	for _, tc := range moveGenTestData {
 		t.Run(tc.name, func(t *testing.T) {
 			ai := makeMoveGenerator(tc.dictionary)
-			board := core.NewBoard()
+			board := core.NewBoard(core.ScrabbleLayout)
 			for _, m := range tc.previousMoves {
 				board.PlaceTiles(m)
 			}
 			rack := core.NewConsumableRack(tiles(tc.rack))
 			moves := collectMoves(board, rack, ai)
 			assert.Subset(t, moves, tc.expectedMoves, tc.name)
+
+			seen := map[core.MoveKey]bool{}
+			for _, m := range moves {
+				key := board.MoveKey(m)
+				assert.False(t, seen[key], "%v generated twice", m)
+				seen[key] = true
+				assert.Equal(t, board.CanonicalMove(m), m, "%v is not canonical", m)
+			}
+		})
+	}
+}
+
+func TestGeneratorsStop(t *testing.T) {
+	smarty := ai.NewSmartyAI(wordDB, wordDB)
+	defer smarty.Kill()
+	speedy := ai.NewSpeedyAI(wordDB, wordGaddag)
+	defer speedy.Kill()
+	generators := map[string]ai.MoveGenerator{
+		"smarty": smarty,
+		"speedy": speedy,
+		"brute":  ai.NewBrute(wordDB),
+		"anchor": ai.NewAnchorAI(wordDB, wordDB),
+	}
+	rack := core.NewConsumableRack(tiles("retains"))
+
+	for name, gen := range generators {
+		t.Run(name, func(t *testing.T) {
+			board := core.NewBoard(core.ScrabbleLayout)
+			calls := 0
+			gen.GenerateMoves(context.Background(), board, rack, func(core.Turn) bool {
+				calls++
+				return false
+			})
+			assert.Equal(t, 1, calls, "generation continued after the callback returned false")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			calls = 0
+			gen.GenerateMoves(ctx, board, rack, func(core.Turn) bool {
+				calls++
+				return true
+			})
+			assert.Equal(t, 0, calls, "generation ignored a cancelled context")
 		})
 	}
 }
+
+func TestGenerateMovesSharesBoard(t *testing.T) {
+	board := core.NewBoard(core.ScrabbleLayout)
+	board.PlaceTiles(core.PlacedTiles{Word: tiles("cat"), Row: 7, Col: 6, Direction: core.Horizontal})
+	rack := core.NewConsumableRack(tiles("retains"))
+	smarty := ai.NewSmartyAI(wordDB, wordDB)
+	defer smarty.Kill()
+	speedy := ai.NewSpeedyAI(wordDB, wordGaddag)
+	defer speedy.Kill()
+
+	var wg sync.WaitGroup
+	for _, gen := range []ai.MoveGenerator{smarty, speedy, ai.NewAnchorAI(wordDB, wordDB)} {
+		wg.Add(1)
+		go func(gen ai.MoveGenerator) {
+			defer wg.Done()
+			collectMoves(board, rack, gen)
+		}(gen)
+	}
+	wg.Wait()
+	assert.False(t, board.HasCrossChecks(wordDB), "generators changed the board")
+}
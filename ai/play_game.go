@@ -1,111 +1,117 @@
 package ai
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 
 	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/core/game"
 	"github.com/Logiraptor/word-bot/persist"
+	"github.com/Logiraptor/word-bot/wordlist"
 )
 
 type Player struct {
-	ai    AI
-	name  string
-	rack  core.Rack
-	score core.Score
+	ai         AI
+	name       string
+	challenger Challenger
 }
 
 func NewPlayer(ai AI) *Player {
 	return &Player{
 		ai:   ai,
 		name: ai.Name(),
-		rack: core.NewConsumableRack(nil),
 	}
 }
 
-func (p *Player) takeTurn(wordDB core.WordList, board *core.Board, bag core.Bag) (core.Bag, []core.Tile, core.ScoredMove, bool) {
-	var turn core.Turn
-	p.ai.FindMove(board, bag, p.rack, func(t core.Turn) bool {
+// WithChallenger lets the player challenge their opponent's moves
+func (p *Player) WithChallenger(c Challenger) *Player {
+	p.challenger = c
+	return p
+}
+
+func (p *Player) challenge(state *game.State) bool {
+	return p.challenger != nil && state.CanChallenge() && p.challenger.Challenge(state.ChallengeableWords())
+}
+
+func (p *Player) takeTurn(state *game.State) core.Turn {
+	var turn core.Turn = core.Pass{}
+	p.ai.FindMove(context.Background(), state.Board, state.Bag, state.CurrentPlayer().Rack, func(t core.Turn) bool {
 		turn = t
 		return true
 	})
-	if turn == nil {
-		return bag, nil, core.ScoredMove{}, false
-	}
-
-	switch move := turn.(type) {
-	case core.ScoredMove:
-		if !board.ValidateMove(move.PlacedTiles, wordDB) {
-			fmt.Printf("%s played an invalid move: %v!\n", p.name, move)
-			return bag, nil, core.ScoredMove{}, false
-		}
-
-		newRack, ok := p.rack.Play(move.Word)
-		if !ok {
-			return bag, nil, core.ScoredMove{}, false
-		}
-
-		p.rack = newRack
-
-		score := board.Score(move.PlacedTiles)
-		board.PlaceTiles(move.PlacedTiles)
-
-		leave := newRack.Rack
-		bag, p.rack.Rack = bag.FillRack(p.rack.Rack, 7-len(p.rack.Rack))
-
-		p.score += score
-
-		return bag, leave, move, true
-	case core.Pass:
-		return bag, nil, core.ScoredMove{}, false
-	case core.Exchange:
-		newRack := core.NewConsumableRack(nil)
-		bag, newRack.Rack = bag.FillRack(newRack.Rack, 7-len(newRack.Rack))
-		bag = bag.Replace(p.rack.Rack)
-		p.rack = newRack
-		bag, p.rack.Rack = bag.FillRack(p.rack.Rack, 7-len(p.rack.Rack))
-		return bag, nil, core.ScoredMove{}, false
-	default:
-		panic(fmt.Sprintf("%s played unknown turn type: %#v", p.ai.Name(), move))
-	}
+	return turn
 }
 
+// PlayGame plays a game of standard English Scrabble between two players
 func PlayGame(wordDB core.WordList, a, b func(board *core.Board) *Player) persist.Game {
-	game := persist.Game{}
+	return PlayGameWithRules(wordDB, core.ScrabbleLayout, core.EnglishScrabble, game.VoidChallenge, a, b)
+}
 
-	swapped := false
+// PlayGameWithRules plays a game on the given board layout with the given
+// tiles until a player goes out or there are too many scoreless turns.
+// Invalid moves are recorded as passes.
+func PlayGameWithRules(wordDB core.WordList, layout *core.BoardLayout, tiles *core.TileSet, rule game.ChallengeRule, a, b func(board *core.Board) *Player) persist.Game {
 	if rand.Intn(2) == 0 {
-		swapped = true
 		a, b = b, a
 	}
 
-	board := core.NewBoard()
-	p1 := a(board)
-	p2 := b(board)
-
-	bag := core.NewConsumableBag().Shuffle()
+	board := core.NewBoard(layout)
+	board.TileSet = tiles
+	// generators searching with wordDB reuse these instead of copying the board
+	board.EnableCrossChecks(wordDB)
+	players := []*Player{a(board), b(board)}
+
+	state := game.New(wordDB, board, core.NewConsumableBag(tiles).Shuffle(), players[0].name, players[1].name)
+	state.Rule = rule
+
+	for {
+		p := players[state.Current]
+		if p.challenge(state) {
+			_, err := state.Play(state.ChallengeLastMove())
+			if err == nil {
+				continue
+			}
+			fmt.Printf("%s could not challenge: %s\n", p.name, err)
+		}
+		if state.IsOver() {
+			break
+		}
 
-	bag, p1.rack.Rack = bag.FillRack(p1.rack.Rack, 7)
-	bag, p2.rack.Rack = bag.FillRack(p2.rack.Rack, 7)
+		_, err := state.Play(p.takeTurn(state))
+		if err != nil {
+			fmt.Printf("%s played an invalid move: %s\n", p.name, err)
+			state.Play(core.Pass{})
+		}
+	}
 
-	var (
-		p1Ok, p2Ok = true, true
-		move       core.ScoredMove
-		leave      []core.Tile
-	)
+	return recordGame(state, players)
+}
 
-	for bag.Count() > 0 && (p1Ok || p2Ok) {
-		if bag, leave, move, p1Ok = p1.takeTurn(wordDB, board, bag); p1Ok {
-			game.AddMove(p1.name, leave, move)
-		}
-		if bag, leave, move, p2Ok = p2.takeTurn(wordDB, board, bag); p2Ok {
-			game.AddMove(p2.name, leave, move)
+func recordGame(state *game.State, players []*Player) persist.Game {
+	record := persist.Game{Alphabet: state.Board.TileSet.Alphabet.Name}
+	if lexicon, ok := state.WordList.(*wordlist.Lexicon); ok {
+		record.Lexicon = lexicon.Name
+	}
+	for _, event := range state.History {
+		name := players[event.Player].name
+		switch t := event.Turn.(type) {
+		case core.ScoredMove:
+			if event.Withdrawn {
+				record.AddPhony(name, t)
+			} else {
+				record.AddMove(name, event.Leave, t)
+			}
+		case core.Exchange:
+			record.AddExchange(name, event.Leave, t)
+		case core.ChallengeWord:
+			if event.Awarded != 0 {
+				record.AddChallengeAward(players[(event.Player+len(players)-1)%len(players)].name, event.Awarded)
+			}
 		}
 	}
-
-	if swapped {
-		p1, p2 = p2, p1
+	for _, adj := range state.Adjustments {
+		record.AddRackAdjustment(players[adj.Player].name, adj.Rack, adj.Score)
 	}
-
-	return game
+	return record
 }
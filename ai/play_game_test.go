@@ -0,0 +1,29 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+	"github.com/stretchr/testify/assert"
+)
+
+type passer struct{}
+
+func (passer) FindMove(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool) {
+}
+func (passer) Name() string { return "passer" }
+
+func TestPlayGameRecordsLexicon(t *testing.T) {
+	player := func(b *core.Board) *ai.Player { return ai.NewPlayer(passer{}) }
+	lexicon := wordlist.WordsLexicon("kids", core.English, []byte("cat\n"))
+
+	record := ai.PlayGame(lexicon, player, player)
+	assert.Equal(t, "kids", record.Lexicon)
+	assert.Len(t, record.Moves, 2, "passes are not recorded, only the final rack adjustments")
+
+	record = ai.PlayGame(wordDB, player, player)
+	assert.Empty(t, record.Lexicon)
+}
@@ -1,6 +1,8 @@
 package ai
 
 import (
+	"context"
+
 	"github.com/Logiraptor/word-bot/core"
 )
 
@@ -16,11 +18,15 @@ func NewPlayout(ai AI) *Playout {
 
 var _ BoardEvaluator = &Playout{}
 
+// Evaluate plays out the rest of the game on a copy of b, so b may be read
+// by other goroutines meanwhile.
 func (p *Playout) Evaluate(b *core.Board, bag core.Bag, p1, p2 core.Rack) float64 {
-	b = b.Clone()
+	board := new(core.Board)
+	board.CopyFrom(b)
+	b = board
 
-	bag, p2.Rack = bag.FillRack(p2.Rack, 7-len(p2.Rack))
-	bag, p1.Rack = bag.FillRack(p1.Rack, 7-len(p1.Rack))
+	bag, p2.Rack = bag.FillRack(p2.Rack, bag.TileSet().RackSize-len(p2.Rack))
+	bag, p1.Rack = bag.FillRack(p1.Rack, bag.TileSet().RackSize-len(p1.Rack))
 
 	var (
 		p1Ok, p2Ok       bool = true, true
@@ -31,7 +37,7 @@ func (p *Playout) Evaluate(b *core.Board, bag core.Bag, p1, p2 core.Rack) float6
 	for (bag.Count() > 0 || len(p2.Rack) > 0 || len(p1.Rack) > 0) && (p1Ok || p2Ok) {
 		p1Ok, p2Ok = false, false
 		var move core.Turn
-		p.ai.FindMove(b, bag, p2, func(turn core.Turn) bool {
+		p.ai.FindMove(context.Background(), b, bag, p2, func(turn core.Turn) bool {
 			move = turn
 			return true
 		})
@@ -40,13 +46,13 @@ func (p *Playout) Evaluate(b *core.Board, bag core.Bag, p1, p2 core.Rack) float6
 		if p2Ok {
 			if p2, p2Ok = p2.Play(pt.Word); p2Ok {
 				b.PlaceTiles(pt.PlacedTiles)
-				bag, p2.Rack = bag.FillRack(p2.Rack, 7-len(p2.Rack))
+				bag, p2.Rack = bag.FillRack(p2.Rack, bag.TileSet().RackSize-len(p2.Rack))
 				p2Score += pt.Score
 			}
 		}
 
 		move = nil
-		p.ai.FindMove(b, bag, p1, func(turn core.Turn) bool {
+		p.ai.FindMove(context.Background(), b, bag, p1, func(turn core.Turn) bool {
 			move = turn
 			return true
 		})
@@ -55,7 +61,7 @@ func (p *Playout) Evaluate(b *core.Board, bag core.Bag, p1, p2 core.Rack) float6
 		if p1Ok {
 			if p1, p1Ok = p1.Play(pt.Word); p1Ok {
 				b.PlaceTiles(pt.PlacedTiles)
-				bag, p1.Rack = bag.FillRack(p1.Rack, 7-len(p1.Rack))
+				bag, p1.Rack = bag.FillRack(p1.Rack, bag.TileSet().RackSize-len(p1.Rack))
 				p1Score += pt.Score
 			}
 		}
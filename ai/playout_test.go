@@ -0,0 +1,30 @@
+package ai_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+)
+
+func TestPlayoutLeavesBoardAlone(t *testing.T) {
+	board := core.NewBoard(core.ScrabbleLayout)
+	board.PlaceTiles(core.PlacedTiles{Word: tiles("cat"), Row: 7, Col: 6, Direction: core.Horizontal})
+	bag := core.NewConsumableBag(core.EnglishScrabble)
+	bag = bag.ConsumeTiles(bag.Remaining()[:90])
+	playout := ai.NewPlayout(ai.NewAnchorAI(wordDB, wordDB))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			playout.Evaluate(board, bag, core.NewConsumableRack(tiles("retains")), core.NewConsumableRack(tiles("eodlr")))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []core.PlacedTiles{{Word: tiles("cat"), Row: 7, Col: 6, Direction: core.Horizontal}}, board.Moves())
+}
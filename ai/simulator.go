@@ -0,0 +1,295 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sort"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// Simulator chooses between the moves Evaluator rates best by playing the
+// turns after each of them many times, like Quackle's simulator. Each time,
+// the tiles the player can't see are shuffled and the opponent's rack and
+// every draw come from them. Both players choose the move Evaluator rates
+// best, and each player's last move is valued by Evaluator rather than its
+// score, so the leave they are left with counts.
+type Simulator struct {
+	// Generator finds the moves of every turn. It is used from many
+	// goroutines at once.
+	Generator MoveGenerator
+	Evaluator MoveEvaluator
+	// Candidates is the number of moves simulated
+	Candidates int
+	// Depth is the number of turns played after each candidate
+	Depth int
+	// Iterations limits the number of times each candidate is simulated.
+	// After MinIterations, simulation stops early once the leader's mean is
+	// more than Confidence standard errors ahead of every other candidate's.
+	Iterations    int
+	MinIterations int
+	Confidence    float64
+	// Workers is the number of simulations played at once
+	Workers int
+}
+
+var _ AI = &Simulator{}
+
+// NewSimulator makes a Simulator which plays two turns after each of the
+// ten best candidates, up to 200 times
+func NewSimulator(gen MoveGenerator, eval MoveEvaluator) *Simulator {
+	return &Simulator{
+		Generator:     gen,
+		Evaluator:     eval,
+		Candidates:    10,
+		Depth:         2,
+		Iterations:    200,
+		MinIterations: 20,
+		Confidence:    2,
+		Workers:       runtime.NumCPU(),
+	}
+}
+
+// SimResult is what simulating a candidate found. Equity is the mean of the
+// points the player finished ahead by over the simulated turns, and WinRate
+// is the fraction of simulations the player finished ahead in once spread
+// is added, counting ties as half.
+type SimResult struct {
+	RankedMove
+	Iterations int
+	Equity     float64
+	StdDev     float64
+	WinRate    float64
+}
+
+// FindMove plays the move Evaluator rates best, and then the move the
+// simulation prefers if it differs. The simulation's choice is only played
+// if every candidate was simulated MinIterations times before ctx was done.
+// bag is the game's bag, which doesn't hold the opponent's rack, so the
+// simulations draw from UnseenTiles instead.
+func (s *Simulator) FindMove(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, onMove func(core.Turn) bool) {
+	candidates := TopMoves(ctx, s.Generator, s.Evaluator, b, rack, s.Candidates)
+	if len(candidates) == 0 || !onMove(candidates[0].ScoredMove) {
+		return
+	}
+	results := s.simulate(ctx, b, UnseenTiles(b, rack), rack, 0, candidates)
+	for _, r := range results {
+		if r.Iterations < s.MinIterations {
+			return
+		}
+	}
+	if best := results[0]; b.MoveKey(best.PlacedTiles) != b.MoveKey(candidates[0].PlacedTiles) {
+		onMove(best.ScoredMove)
+	}
+}
+
+// UnseenTiles returns a bag of the tiles the player with rack can't see:
+// the board's tile set less the tiles on the board and in the rack
+func UnseenTiles(b *core.Board, rack core.Rack) core.Bag {
+	seen := make([]core.Tile, 0, b.Layout.Rows*b.Layout.Cols+len(rack.Rack))
+	for row := 0; row < b.Layout.Rows; row++ {
+		for col := 0; col < b.Layout.Cols; col++ {
+			if tile := b.TileAt(row, col); !tile.IsNoTile() {
+				seen = append(seen, tile)
+			}
+		}
+	}
+	for i, tile := range rack.Rack {
+		if rack.CanConsume(i) {
+			seen = append(seen, tile)
+		}
+	}
+	return core.NewConsumableBag(b.TileSet).ConsumeTiles(seen)
+}
+
+func (s *Simulator) Name() string {
+	return "Sim"
+}
+
+// Simulate analyzes a position: it simulates the best candidates for rack,
+// where bag holds every tile the player can't see and the player is spread
+// points ahead, and returns them with the best Equity first. It returns
+// early with what it has found when ctx is done.
+func (s *Simulator) Simulate(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, spread core.Score) []SimResult {
+	candidates := TopMoves(ctx, s.Generator, s.Evaluator, b, rack, s.Candidates)
+	return s.simulate(ctx, b, bag, rack, spread, candidates)
+}
+
+// simStats accumulates the outcomes of a candidate with Welford's algorithm
+type simStats struct {
+	n        int
+	mean, m2 float64
+	wins     float64
+}
+
+func (st *simStats) add(outcome float64, spread core.Score) {
+	st.n++
+	delta := outcome - st.mean
+	st.mean += delta / float64(st.n)
+	st.m2 += delta * (outcome - st.mean)
+	switch final := outcome + float64(spread); {
+	case final > 0:
+		st.wins++
+	case final == 0:
+		st.wins += 0.5
+	}
+}
+
+func (st *simStats) stdDev() float64 {
+	if st.n < 2 {
+		return 0
+	}
+	return math.Sqrt(st.m2 / float64(st.n-1))
+}
+
+func (st *simStats) stdErr() float64 {
+	if st.n < 2 {
+		return math.Inf(1)
+	}
+	return st.stdDev() / math.Sqrt(float64(st.n))
+}
+
+type simOutcome struct {
+	candidate int
+	value     float64
+}
+
+func (s *Simulator) simulate(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, spread core.Score, candidates []RankedMove) []SimResult {
+	stats := make([]simStats, len(candidates))
+	workers := s.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	// boards copied from one with cross-checks keep them up to date as moves
+	// are played out, rather than each search rebuilding them
+	if gen, ok := s.Generator.(crossCheckGenerator); ok {
+		b = b.WithCrossChecks(gen.crossCheckWords())
+	}
+
+	jobs := make(chan int)
+	// every outcome of a round fits, so workers never wait on the round
+	outcomes := make(chan simOutcome, len(candidates))
+	done := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			board := new(core.Board)
+			for c := range jobs {
+				board.CopyFrom(b)
+				value := s.playOut(ctx, board, bag, rack, candidates[c].ScoredMove)
+				outcomes <- simOutcome{candidate: c, value: value}
+			}
+		}()
+	}
+	defer func() {
+		close(jobs)
+		for i := 0; i < workers; i++ {
+			<-done
+		}
+	}()
+
+	for round := 0; round < s.Iterations && len(candidates) > 0; round++ {
+		sent := 0
+	send:
+		for c := range candidates {
+			select {
+			case jobs <- c:
+				sent++
+			case <-ctx.Done():
+				break send
+			}
+		}
+		for i := 0; i < sent; i++ {
+			o := <-outcomes
+			// simulations cut short by ctx would skew the results
+			if ctx.Err() == nil {
+				stats[o.candidate].add(o.value, spread)
+			}
+		}
+		if ctx.Err() != nil || round+1 >= s.MinIterations && s.dominated(stats) {
+			break
+		}
+	}
+
+	results := make([]SimResult, len(candidates))
+	for i, c := range candidates {
+		st := stats[i]
+		results[i] = SimResult{
+			RankedMove: c,
+			Iterations: st.n,
+			Equity:     st.mean,
+			StdDev:     st.stdDev(),
+		}
+		if st.n > 0 {
+			results[i].WinRate = st.wins / float64(st.n)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Equity > results[j].Equity })
+	return results
+}
+
+// dominated reports whether the leader is confidently ahead of the rest
+func (s *Simulator) dominated(stats []simStats) bool {
+	leader := 0
+	for i := range stats {
+		if stats[i].mean > stats[leader].mean {
+			leader = i
+		}
+	}
+	floor := stats[leader].mean - s.Confidence*stats[leader].stdErr()
+	for i := range stats {
+		if i != leader && stats[i].mean+s.Confidence*stats[i].stdErr() >= floor {
+			return false
+		}
+	}
+	return true
+}
+
+// playOut plays move and the turns after it on board, and returns how many
+// points the player finished ahead by
+func (s *Simulator) playOut(ctx context.Context, board *core.Board, bag core.Bag, rack core.Rack, move core.ScoredMove) float64 {
+	rackSize := board.TileSet.RackSize
+	bag = bag.Shuffle()
+	bag, opponent := bag.FillRack(nil, rackSize)
+	racks := [2]core.Rack{rack, core.NewConsumableRack(opponent)}
+
+	outcome := 0.0
+	for ply := 0; ; ply++ {
+		player := ply % 2
+		sign := 1.0
+		if player == 1 {
+			sign = -1
+		}
+
+		if len(move.Word) > 0 {
+			value := float64(move.Score)
+			if ply+2 > s.Depth {
+				// the player's last move, so its leave is valued too
+				value = s.Evaluator.Evaluate(board, racks[player], move)
+			}
+			outcome += sign * value
+
+			board.PlaceTiles(move.PlacedTiles)
+			leave, _ := racks[player].Play(move.Word)
+			var drawn []core.Tile
+			bag, drawn = bag.FillRack(leave.Rack, rackSize-len(leave.Rack))
+			racks[player] = core.NewConsumableRack(drawn)
+			if len(drawn) == 0 {
+				// going out earns the value of the other rack, which its
+				// owner loses
+				outcome += sign * 2 * float64(board.TileSet.Sum(racks[1-player].Rack))
+				return outcome
+			}
+		}
+
+		if ply == s.Depth {
+			return outcome
+		}
+		move = core.ScoredMove{}
+		if next := TopMoves(ctx, s.Generator, s.Evaluator, board, racks[1-player], 1); len(next) > 0 {
+			move = next[0].ScoredMove
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/internal/leaktest"
+)
+
+func smallSimulator() *ai.Simulator {
+	sim := ai.NewSimulator(ai.NewAnchorAI(wordDB, wordDB), ai.NewEquity(nil))
+	sim.Candidates = 3
+	sim.Iterations = 8
+	sim.MinIterations = 2
+	sim.Workers = 2
+	return sim
+}
+
+func unseen(rack []core.Tile) core.Bag {
+	return core.NewConsumableBag(core.EnglishScrabble).ConsumeTiles(rack)
+}
+
+func TestSimulate(t *testing.T) {
+	defer leaktest.Check(t)()
+	sim := smallSimulator()
+	rack := tiles("retains")
+	board := core.NewBoard(core.ScrabbleLayout)
+
+	results := sim.Simulate(context.Background(), board, unseen(rack), core.NewConsumableRack(rack), 0)
+	if assert.Len(t, results, 3) {
+		for i, r := range results {
+			if i > 0 {
+				assert.True(t, results[i-1].Equity >= r.Equity, "results are out of order")
+			}
+			assert.True(t, r.Iterations >= sim.MinIterations && r.Iterations <= sim.Iterations, "simulated %d times", r.Iterations)
+			assert.True(t, r.WinRate >= 0 && r.WinRate <= 1, "win rate %f", r.WinRate)
+			assert.True(t, r.StdDev >= 0)
+		}
+	}
+	assert.True(t, board.IsEmpty(), "the board was changed")
+}
+
+func TestSimulateStopsWhenDominant(t *testing.T) {
+	sim := smallSimulator()
+	sim.Candidates = 2
+	sim.Iterations = 200
+	sim.Confidence = 0.5
+	// with a loose confidence, the best bingo soon pulls ahead
+	rack := tiles("retains")
+	results := sim.Simulate(context.Background(), core.NewBoard(core.ScrabbleLayout), unseen(rack), core.NewConsumableRack(rack), 0)
+	if assert.Len(t, results, 2) {
+		assert.Len(t, results[0].Word, 7)
+		assert.True(t, results[0].Iterations < sim.Iterations, "simulation didn't stop early")
+	}
+}
+
+func TestSimulateSpread(t *testing.T) {
+	sim := smallSimulator()
+	rack := tiles("retains")
+	results := sim.Simulate(context.Background(), core.NewBoard(core.ScrabbleLayout), unseen(rack), core.NewConsumableRack(rack), 1000)
+	for _, r := range results {
+		assert.Equal(t, 1.0, r.WinRate)
+	}
+}
+
+func TestSimulateCancelled(t *testing.T) {
+	defer leaktest.Check(t)()
+	sim := smallSimulator()
+	sim.Iterations = 1000000
+	sim.Confidence = 1000
+	rack := tiles("retains")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	results := sim.Simulate(ctx, core.NewBoard(core.ScrabbleLayout), unseen(rack), core.NewConsumableRack(rack), 0)
+	assert.True(t, time.Since(start) < 5*time.Second, "simulation ignored ctx")
+	for _, r := range results {
+		assert.True(t, r.Iterations < sim.Iterations)
+	}
+}
+
+func TestSimulatorFindMove(t *testing.T) {
+	defer leaktest.Check(t)()
+	sim := smallSimulator()
+	rack := tiles("retains")
+	var moves []core.Turn
+	sim.FindMove(context.Background(), core.NewBoard(core.ScrabbleLayout), unseen(rack), core.NewConsumableRack(rack), func(turn core.Turn) bool {
+		moves = append(moves, turn)
+		return true
+	})
+	if assert.NotEmpty(t, moves) {
+		assert.True(t, len(moves) <= 2)
+		for _, m := range moves {
+			assert.Len(t, m.(core.ScoredMove).Word, 7)
+		}
+	}
+}
+
+func TestSimulatorFindMoveEmptyBag(t *testing.T) {
+	sim := smallSimulator()
+	board := core.NewBoard(core.ScrabbleLayout)
+	board.PlaceTiles(core.PlacedTiles{Word: tiles("Cat"), Row: 7, Col: 6, Direction: core.Horizontal})
+	rack := core.NewConsumableRack(tiles("retains"))
+
+	// the game's bag is empty, but the opponent's rack is still unseen
+	pool := ai.UnseenTiles(board, rack)
+	assert.Equal(t, core.EnglishScrabble.Size()-10, pool.Count())
+	blanks := 0
+	for _, tile := range pool.Remaining() {
+		if tile.IsBlank() {
+			blanks++
+		}
+	}
+	assert.Equal(t, 1, blanks, "the blank on the board was counted as unseen")
+
+	empty := core.NewConsumableBag(core.EnglishScrabble)
+	empty = empty.ConsumeTiles(empty.Remaining())
+	var moves []core.Turn
+	sim.FindMove(context.Background(), board, empty, rack, func(turn core.Turn) bool {
+		moves = append(moves, turn)
+		return true
+	})
+	assert.NotEmpty(t, moves)
+}
@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"context"
 	"runtime"
 	"sync"
 
@@ -10,34 +11,34 @@ import (
 
 type SmartyAI struct {
 	wordList    core.WordList
-	jobs        chan<- job
 	searchSpace *wordlist.Trie
+	engine      *Engine
+	// ownEngine is set when Kill should close engine
+	ownEngine bool
 }
 
 var _ AI = &SmartyAI{}
 var _ MoveGenerator = &SmartyAI{}
+var _ crossCheckGenerator = &SmartyAI{}
 
 var blankA = core.Rune2Letter('a').ToTile(true)
 var blankZ = core.Rune2Letter('z').ToTile(true)
 
-func NewSmartyAI(wordList core.WordList, searchSpace *wordlist.Trie) *SmartyAI {
-
-	jobs := make(chan job, 15*15*2)
-
-	s := &SmartyAI{
-		wordList:    wordList,
-		searchSpace: searchSpace,
-		jobs:        jobs,
-	}
-
-	for i := 0; i < runtime.NumCPU(); i++ {
-		go searchWorker(s, jobs)
-	}
+// lastBlank is the highest letter any alphabet can assign to a blank. Letters
+// beyond the end of the word list's alphabet never branch, so searches that
+// try every blank up to lastBlank work for any alphabet.
+var lastBlank = core.Letter(core.MaxLetters - 1).ToTile(true)
 
+// NewSmartyAI makes a SmartyAI with its own workers, one per CPU, which
+// live until Kill. Use Engine.NewSmartyAI to share workers between AIs.
+func NewSmartyAI(wordList core.WordList, searchSpace *wordlist.Trie) *SmartyAI {
+	s := NewEngine(runtime.NumCPU()).NewSmartyAI(wordList, searchSpace)
+	s.ownEngine = true
 	return s
 }
 
 type job struct {
+	ctx        context.Context
 	i, j       int
 	board      *core.Board
 	dir        core.Direction
@@ -47,9 +48,9 @@ type job struct {
 	wg         *sync.WaitGroup
 }
 
-func (s *SmartyAI) FindMove(b *core.Board, bag core.Bag, rack core.Rack, callback func(core.Turn) bool) {
+func (s *SmartyAI) FindMove(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, callback func(core.Turn) bool) {
 	var bestMove core.ScoredMove
-	s.GenerateMoves(b, rack, func(turn core.Turn) bool {
+	s.GenerateMoves(ctx, b, rack, func(turn core.Turn) bool {
 		switch x := turn.(type) {
 		case core.ScoredMove:
 			if x.Score > bestMove.Score {
@@ -61,78 +62,108 @@ func (s *SmartyAI) FindMove(b *core.Board, bag core.Bag, rack core.Rack, callbac
 	})
 }
 
-func (s *SmartyAI) GenerateMoves(b *core.Board, rack core.Rack, callback func(core.Turn) bool) {
+// GenerateMoves searches from every empty square on the worker pool. When
+// callback returns false or ctx is done, jobs not yet started are skipped
+// and GenerateMoves returns once the running ones finish.
+func (s *SmartyAI) GenerateMoves(ctx context.Context, b *core.Board, rack core.Rack, callback func(core.Turn) bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	callback = distinctMoves(b, callback)
+	b = b.WithCrossChecks(s.wordList)
 	var wg = new(sync.WaitGroup)
 	dirs := []core.Direction{core.Horizontal, core.Vertical}
 	results := make(chan core.PlacedTiles, 10)
 
 	go func() {
-		for i := 0; i < 15; i++ {
-			for j := 0; j < 15; j++ {
+		defer close(results)
+		defer wg.Wait()
+		for i := 0; i < b.Layout.Rows; i++ {
+			for j := 0; j < b.Layout.Cols; j++ {
 				if b.HasTile(i, j) {
 					continue
 				}
 				for _, dir := range dirs {
 					wg.Add(1)
-					s.jobs <- job{
+					job := job{
+						ctx:   ctx,
 						board: b, i: i, j: j, dir: dir,
 						rack:  rack, resultChan: results,
 						wg:    wg, wordDB: s.searchSpace,
 					}
+					if !s.engine.run(ctx, func() { s.runJob(job) }) {
+						wg.Done()
+						return
+					}
 				}
 			}
 		}
-
-		wg.Wait()
-		close(results)
 	}()
 
+	// drain the results even after stopping, so no job outlives this call
 	for result := range results {
+		if ctx.Err() != nil {
+			continue
+		}
 		score := b.Score(result)
-		callback(core.ScoredMove{
+		if !callback(core.ScoredMove{
 			PlacedTiles: result,
 			Score:       score,
-		})
+		}) {
+			cancel()
+		}
 	}
 }
 
-func searchWorker(s *SmartyAI, jobs <-chan job) {
+func (s *SmartyAI) runJob(job job) {
+	defer job.wg.Done()
+	if job.ctx.Err() != nil {
+		return
+	}
 	var tiles = make([]core.Tile, 0, 15)
-	for job := range jobs {
-		s.Search(job.board, job.i, job.j, job.dir, job.rack, job.wordDB, tiles, func(word []core.Tile) {
-			if len(word) == 0 {
-				return
-			}
+	s.Search(job.board, job.i, job.j, job.dir, job.rack, job.wordDB, tiles, func(word []core.Tile) {
+		if len(word) == 0 {
+			return
+		}
 
-			result := core.PlacedTiles{
-				Word:      word,
-				Row:       job.i,
-				Col:       job.j,
-				Direction: job.dir,
-			}
-			if job.board.ValidateMove(result, s.wordList) {
-				_, canPlay := job.rack.Play(word)
-				if canPlay {
-					newWord := make([]core.Tile, len(result.Word))
-					copy(newWord, result.Word)
-					result.Word = newWord
-
-					job.resultChan <- result
+		result := core.PlacedTiles{
+			Word:      word,
+			Row:       job.i,
+			Col:       job.j,
+			Direction: job.dir,
+		}
+		if job.board.ValidateMove(result, s.wordList) {
+			_, canPlay := job.rack.Play(word)
+			if canPlay {
+				newWord := make([]core.Tile, len(result.Word))
+				copy(newWord, result.Word)
+				result.Word = newWord
+
+				select {
+				case job.resultChan <- result:
+				case <-job.ctx.Done():
 				}
 			}
-		})
-		job.wg.Done()
-	}
+		}
+	})
 }
 
+// Kill stops the workers of an AI made by NewSmartyAI. AIs made by an Engine
+// share its workers, so Kill leaves them running and Engine.Close stops them.
 func (s *SmartyAI) Kill() {
-	close(s.jobs)
+	if s.ownEngine {
+		s.engine.Close()
+	}
 }
 
 func (s *SmartyAI) Name() string {
 	return "Smarty"
 }
 
+func (s *SmartyAI) crossCheckWords() core.WordList {
+	return s.wordList
+}
+
+// Search finds words starting at i, j. The board's cross-checks must be enabled.
 func (s *SmartyAI) Search(board *core.Board, i, j int, dir core.Direction, rack core.Rack, wordDB *wordlist.Trie, prev []core.Tile, callback func([]core.Tile)) {
 	// backup to next blank
 	dRow, dCol := dir.Offsets()
@@ -147,7 +178,7 @@ func (s *SmartyAI) Search(board *core.Board, i, j int, dir core.Direction, rack
 	// prepare trie for word generation
 	for (row < i || col < j) && board.HasTile(row, col) {
 		var ok bool
-		t := board.Cells[row][col].Tile
+		t := board.TileAt(row, col)
 		wordDB, ok = wordDB.CanBranch(t)
 		if !ok {
 			return
@@ -169,7 +200,7 @@ func (s *SmartyAI) searchRest(board *core.Board, i, j int, dir core.Direction, r
 		return
 	}
 	if board.HasTile(i, j) {
-		letter := board.Cells[i][j].Tile
+		letter := board.TileAt(i, j)
 		if next, ok := wordDB.CanBranch(letter); ok {
 			s.searchRest(board, i+dRow, j+dCol, dir, rack, next, prev, callback)
 		}
@@ -179,16 +210,16 @@ func (s *SmartyAI) searchRest(board *core.Board, i, j int, dir core.Direction, r
 				continue
 			}
 			if letter.IsBlank() {
-				for r := blankA; r <= blankZ; r++ {
+				for r := blankA; r <= lastBlank; r++ {
 					if next, ok := wordDB.CanBranch(r); ok {
-						if s.validateCrossWord(board, i, j, r, !dir) {
+						if board.CrossCheck(i, j, dir).Contains(r.ToLetter()) {
 							s.searchRest(board, i+dRow, j+dCol, dir, rack.Consume(index), next, append(prev, r), callback)
 						}
 					}
 				}
 			} else {
 				if next, ok := wordDB.CanBranch(letter); ok {
-					if s.validateCrossWord(board, i, j, letter, !dir) {
+					if board.CrossCheck(i, j, dir).Contains(letter.ToLetter()) {
 						s.searchRest(board, i+dRow, j+dCol, dir, rack.Consume(index), next, append(prev, letter), callback)
 					}
 				}
@@ -197,46 +228,3 @@ func (s *SmartyAI) searchRest(board *core.Board, i, j int, dir core.Direction, r
 	}
 }
 
-func (s *SmartyAI) validateCrossWord(board *core.Board, i, j int, placed core.Tile, dir core.Direction) bool {
-	// back up perpendicular to advancing direction until I hit a blank
-	var (
-		ok                 bool
-		perpDRow, perpDCol = dir.Offsets()
-		perpI, perpJ       = i-perpDRow, j-perpDCol
-	)
-
-	if !board.HasTile(i+perpDRow, j+perpDCol) && !board.HasTile(i-perpDRow, j-perpDCol) {
-		return true
-	}
-
-	for board.HasTile(perpI, perpJ) {
-		perpI -= perpDRow
-		perpJ -= perpDCol
-	}
-	// go back to the last tile I was on
-	perpI += perpDRow
-	perpJ += perpDCol
-
-	// validate continuous string of tiles is a word
-	wordRoot := s.searchSpace
-	for {
-		if board.HasTile(perpI, perpJ) {
-			t := board.Cells[perpI][perpJ].Tile
-			if wordRoot, ok = wordRoot.CanBranch(t); !ok {
-				// This is not a word, bail out
-				return false
-			}
-		} else if perpI == i && perpJ == j {
-			if wordRoot, ok = wordRoot.CanBranch(placed); !ok {
-				return false
-			}
-		} else {
-			break
-		}
-		perpI += perpDRow
-		perpJ += perpDCol
-	}
-
-	// if so, recurse on search
-	return wordRoot.IsTerminal()
-}
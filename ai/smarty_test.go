@@ -1,6 +1,7 @@
 package ai_test
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -24,7 +25,7 @@ func TestSmartyMatchesBrute(t *testing.T) {
 		t.Skip("Brute is too slow")
 	}
 	tiles := core.NewConsumableRack(core.MakeTiles(core.MakeWord("asdjdha"), "xxxxxx "))
-	board := core.NewBoard()
+	board := core.NewBoard(core.ScrabbleLayout)
 
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("doggo"), "xxxxx"), 7, 7, core.Horizontal})
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("ar"), "xx"), 7, 8, core.Vertical})
@@ -33,19 +34,17 @@ func TestSmartyMatchesBrute(t *testing.T) {
 	defer smarty.Kill()
 
 	smartyMoves := []core.Turn{}
-	smarty.GenerateMoves(board, tiles, func(t core.Turn) bool {
+	smarty.GenerateMoves(context.Background(), board, tiles, func(t core.Turn) bool {
 		smartyMoves = append(smartyMoves, t)
 		return true
 	})
 
 	bruteMoves := []core.Turn{}
-	ai.BruteForce(board, tiles, wordDB, func(t core.Turn) {
+	ai.NewBrute(wordDB).GenerateMoves(context.Background(), board, tiles, func(t core.Turn) bool {
 		bruteMoves = append(bruteMoves, t)
+		return true
 	})
 
-	smartyMoves = unique(smartyMoves)
-	bruteMoves = unique(bruteMoves)
-
 	assert.Subset(t, bruteMoves, smartyMoves)
 	if !assert.Equal(t, len(bruteMoves), len(smartyMoves)) {
 		compareSets(board, "brute", "smarty", bruteMoves, smartyMoves)
@@ -55,9 +54,9 @@ func TestSmartyMatchesBrute(t *testing.T) {
 
 func BenchmarkSmarty(b *testing.B) {
 	tiles := core.NewConsumableRack(core.MakeTiles(core.MakeWord("bdhrigs"), "xxxxxx "))
-	board := core.NewBoard()
+	board := core.NewBoard(core.ScrabbleLayout)
 	smarty := ai.NewSmartyAI(wordDB, wordDB)
-	bag := core.NewConsumableBag()
+	bag := core.NewConsumableBag(core.EnglishScrabble)
 	defer smarty.Kill()
 
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 0, 7, core.Vertical})
@@ -65,18 +64,19 @@ func BenchmarkSmarty(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		smarty.FindMove(board, bag, tiles, func(core.Turn) bool { return true })
+		smarty.FindMove(context.Background(), board, bag, tiles, func(core.Turn) bool { return true })
 	}
 }
 
 func BenchmarkSearch(b *testing.B) {
 	rack := core.NewConsumableRack(core.MakeTiles(core.MakeWord("bdhrigs"), "xxxxxx "))
-	board := core.NewBoard()
+	board := core.NewBoard(core.ScrabbleLayout)
 	smarty := ai.NewSmartyAI(wordDB, wordDB)
 	defer smarty.Kill()
 
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 0, 7, core.Vertical})
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 7, 0, core.Horizontal})
+	board.EnableCrossChecks(wordDB)
 	prev := []core.Tile{}
 
 	b.ResetTimer()
@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"context"
 	"sync"
 
 	"github.com/Logiraptor/word-bot/wordlist"
@@ -10,31 +11,26 @@ import (
 
 type SpeedyAI struct {
 	wordList    core.WordList
-	jobs        chan<- speedyJob
 	searchSpace *wordlist.Gaddag
+	engine      *Engine
+	// ownEngine is set when Kill should close engine
+	ownEngine bool
 }
 
 var _ AI = &SpeedyAI{}
 var _ MoveGenerator = &SpeedyAI{}
+var _ crossCheckGenerator = &SpeedyAI{}
 
+// NewSpeedyAI makes a SpeedyAI with its own worker, which lives until Kill.
+// Use Engine.NewSpeedyAI to share workers between AIs.
 func NewSpeedyAI(wordList core.WordList, searchSpace *wordlist.Gaddag) *SpeedyAI {
-
-	jobs := make(chan speedyJob, 15*15*2)
-
-	s := &SpeedyAI{
-		wordList:    wordList,
-		searchSpace: searchSpace,
-		jobs:        jobs,
-	}
-
-	for i := 0; i < 1; i++ {
-		go speedySearchWorker(s, jobs)
-	}
-
+	s := NewEngine(1).NewSpeedyAI(wordList, searchSpace)
+	s.ownEngine = true
 	return s
 }
 
 type speedyJob struct {
+	ctx        context.Context
 	i, j       int
 	board      *core.Board
 	dir        core.Direction
@@ -44,9 +40,9 @@ type speedyJob struct {
 	wg         *sync.WaitGroup
 }
 
-func (s *SpeedyAI) FindMove(b *core.Board, bag core.Bag, rack core.Rack, callback func(core.Turn) bool) {
+func (s *SpeedyAI) FindMove(ctx context.Context, b *core.Board, bag core.Bag, rack core.Rack, callback func(core.Turn) bool) {
 	var bestMove core.ScoredMove
-	s.GenerateMoves(b, rack, func(turn core.Turn) bool {
+	s.GenerateMoves(ctx, b, rack, func(turn core.Turn) bool {
 		switch x := turn.(type) {
 		case core.ScoredMove:
 			if x.Score > bestMove.Score {
@@ -58,97 +54,116 @@ func (s *SpeedyAI) FindMove(b *core.Board, bag core.Bag, rack core.Rack, callbac
 	})
 }
 
-func (s *SpeedyAI) GenerateMoves(b *core.Board, rack core.Rack, callback func(core.Turn) bool) {
+// GenerateMoves searches from every anchor on the worker pool. When
+// callback returns false or ctx is done, jobs not yet started are skipped
+// and GenerateMoves returns once the running ones finish.
+func (s *SpeedyAI) GenerateMoves(ctx context.Context, b *core.Board, rack core.Rack, callback func(core.Turn) bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	callback = distinctMoves(b, callback)
 	var wg = new(sync.WaitGroup)
 
 	dirs := []core.Direction{core.Horizontal, core.Vertical}
 
 	results := make(chan core.PlacedTiles, 10)
 
+	b = b.WithCrossChecks(s.wordList)
 	go func() {
-		for i := 0; i < 15; i++ {
-			for j := 0; j < 15; j++ {
-				for _, dir := range dirs {
-
-					if !b.HasTile(i, j) && ((i == 7 && j == 7) ||
-						b.HasTile(i-1, j) || b.HasTile(i, j-1) ||
-						b.HasTile(i+1, j) || b.HasTile(i, j+1)) {
-						wg.Add(1)
-						s.jobs <- speedyJob{
-							board:      b,
-							i:          i,
-							j:          j,
-							dir:        dir,
-							rack:       rack,
-							resultChan: results,
-							wg:         wg,
-							wordDB:     s.searchSpace,
-						}
-						// fmt.Println("Pursuing", i, j)
-					}
+		defer close(results)
+		defer wg.Wait()
+		for _, anchor := range b.Anchors() {
+			for _, dir := range dirs {
+				wg.Add(1)
+				job := speedyJob{
+					ctx:        ctx,
+					board:      b,
+					i:          anchor.Row,
+					j:          anchor.Col,
+					dir:        dir,
+					rack:       rack,
+					resultChan: results,
+					wg:         wg,
+					wordDB:     s.searchSpace,
+				}
+				if !s.engine.run(ctx, func() { s.runJob(job) }) {
+					wg.Done()
+					return
 				}
 			}
 		}
-
-		wg.Wait()
-		close(results)
 	}()
 
+	// drain the results even after stopping, so no job outlives this call
 	for result := range results {
+		if ctx.Err() != nil {
+			continue
+		}
 		score := b.Score(result)
-		callback(core.ScoredMove{
+		if !callback(core.ScoredMove{
 			PlacedTiles: result,
 			Score:       score,
-		})
+		}) {
+			cancel()
+		}
 	}
 }
 
-func speedySearchWorker(s *SpeedyAI, jobs <-chan speedyJob) {
+func (s *SpeedyAI) runJob(job speedyJob) {
+	defer job.wg.Done()
+	if job.ctx.Err() != nil {
+		return
+	}
 	var tiles = make([]core.Tile, 0, 15)
-	for job := range jobs {
-		s.Search(job.board, job.i, job.j, job.dir, job.rack, job.wordDB, tiles, func(i, j int, reversePrefix, rest []core.Tile) {
-			// fmt.Println("RECEIVED:", reversePrefix, rest)
-			if len(reversePrefix)+len(rest) == 0 {
-				return
-			}
+	s.Search(job.board, job.i, job.j, job.dir, job.rack, job.wordDB, tiles, func(i, j int, reversePrefix, rest []core.Tile) {
+		// fmt.Println("RECEIVED:", reversePrefix, rest)
+		if len(reversePrefix)+len(rest) == 0 {
+			return
+		}
 
-			word := make([]core.Tile, len(reversePrefix)+len(rest))
-			p := 0
-			for i := len(reversePrefix) - 1; i >= 0; i-- {
-				word[p] = reversePrefix[i]
-				p++
-			}
-			for _, x := range rest {
-				word[p] = x
-				p++
-			}
+		word := make([]core.Tile, len(reversePrefix)+len(rest))
+		p := 0
+		for i := len(reversePrefix) - 1; i >= 0; i-- {
+			word[p] = reversePrefix[i]
+			p++
+		}
+		for _, x := range rest {
+			word[p] = x
+			p++
+		}
 
-			result := core.PlacedTiles{
-				Word:      word,
-				Row:       i,
-				Col:       j,
-				Direction: job.dir,
-			}
-			// fmt.Println("RECONSTRUCTED", result)
-			if job.board.ValidateMove(result, s.wordList) {
-				_, canPlay := job.rack.Play(word)
-				if canPlay {
-					job.resultChan <- result
-				}
-			}
-		})
-		job.wg.Done()
-	}
+		result := core.PlacedTiles{
+			Word:      word,
+			Row:       i,
+			Col:       j,
+			Direction: job.dir,
+		}
+		// fmt.Println("RECONSTRUCTED", result)
+		// Searching from an anchor through the gaddag with cross-checks
+		// only finds legal moves, so there is nothing left to validate.
+		select {
+		case job.resultChan <- result:
+		case <-job.ctx.Done():
+		}
+	})
 }
 
+// Kill stops the worker of an AI made by NewSpeedyAI. AIs made by an Engine
+// share its workers, so Kill leaves them running and Engine.Close stops them.
 func (s *SpeedyAI) Kill() {
-	close(s.jobs)
+	if s.ownEngine {
+		s.engine.Close()
+	}
 }
 
 func (s *SpeedyAI) Name() string {
 	return "Speedy"
 }
 
+func (s *SpeedyAI) crossCheckWords() core.WordList {
+	return s.wordList
+}
+
+// Search finds words through the anchor at i, j. The board's cross-checks must be enabled.
 func (s *SpeedyAI) Search(board *core.Board, i, j int, dir core.Direction, rack core.Rack, wordDB *wordlist.Gaddag, prev []core.Tile, callback func(int, int, []core.Tile, []core.Tile)) {
 	// fmt.Println("CONT: Starting search at ", i, j, dir)
 	// fmt.Println("CONT: With words", wordDB.DumpOptions())
@@ -162,7 +177,7 @@ func (s *SpeedyAI) searchForward(board *core.Board, startI, startJ, row, col int
 	dRow, dCol := dir.Offsets()
 	if board.HasTile(row, col) {
 		// fmt.Println("CONT: Attempting to consume board tile")
-		letter := board.Cells[row][col].Tile
+		letter := board.TileAt(row, col)
 		if !wordDB.CanBranch(letter) {
 			// fmt.Println("BAIL: cannot branch on board tile")
 			return
@@ -188,13 +203,13 @@ func (s *SpeedyAI) searchForward(board *core.Board, startI, startJ, row, col int
 		}
 		if letter.IsBlank() {
 			// fmt.Println("CONT: attempting to consume blank from rack")
-			for r := blankA; r <= blankZ; r++ {
+			for r := blankA; r <= lastBlank; r++ {
 				// fmt.Println("CONT: assigning blank as", r)
 				if !wordDB.CanBranch(r) {
 					// fmt.Println("BAIL: Cannot branch on", r)
 					continue
 				}
-				if !s.validateCrossWord(board, row, col, r, !dir) {
+				if !board.CrossCheck(row, col, dir).Contains(r.ToLetter()) {
 					// fmt.Println("BAIL: cross word is not valid")
 					continue
 				}
@@ -209,7 +224,7 @@ func (s *SpeedyAI) searchForward(board *core.Board, startI, startJ, row, col int
 			// fmt.Println("BAIL: cannot branch on rack tile", letter)
 			continue
 		}
-		if !s.validateCrossWord(board, row, col, letter, !dir) {
+		if !board.CrossCheck(row, col, dir).Contains(letter.ToLetter()) {
 			// fmt.Println("BAIL: cross word is invalid")
 			continue
 		}
@@ -227,7 +242,7 @@ func (s *SpeedyAI) searchBackward(board *core.Board, row, col int, dir core.Dire
 
 	if board.HasTile(row, col) {
 		// fmt.Println("BACK: attempting to consume board tile")
-		letter := board.Cells[row][col].Tile
+		letter := board.TileAt(row, col)
 		if !wordDB.CanBranch(letter) {
 			// fmt.Println("BAIL: cannot branch on board tile", letter)
 			return
@@ -252,13 +267,13 @@ func (s *SpeedyAI) searchBackward(board *core.Board, row, col int, dir core.Dire
 		}
 		if letter.IsBlank() {
 			// fmt.Println("CONT: attempting to consume blank from rack")
-			for r := blankA; r <= blankZ; r++ {
+			for r := blankA; r <= lastBlank; r++ {
 				// fmt.Println("CONT: attempting to assign blank to", r)
 				if !wordDB.CanBranch(r) {
 					// fmt.Println("BAIL: cannot branch on ", r)
 					continue
 				}
-				if !s.validateCrossWord(board, row, col, r, !dir) {
+				if !board.CrossCheck(row, col, dir).Contains(r.ToLetter()) {
 					// fmt.Println("BAIL: cross word is invalid")
 					continue
 				}
@@ -272,7 +287,7 @@ func (s *SpeedyAI) searchBackward(board *core.Board, row, col int, dir core.Dire
 			// fmt.Println("BAIL: cannot branch on ", letter)
 			continue
 		}
-		if !s.validateCrossWord(board, row, col, letter, !dir) {
+		if !board.CrossCheck(row, col, dir).Contains(letter.ToLetter()) {
 			// fmt.Println("BAIL: cross word is invalid")
 			continue
 		}
@@ -281,49 +296,3 @@ func (s *SpeedyAI) searchBackward(board *core.Board, row, col int, dir core.Dire
 		s.searchBackward(board, row+dRow, col+dCol, dir, rack.Consume(i), wordDB.Branch(letter), append(prefix, letter), rest, callback)
 	}
 }
-
-func (s *SpeedyAI) validateCrossWord(board *core.Board, i, j int, placed core.Tile, dir core.Direction) bool {
-	// back up perpendicular to advancing direction until I hit a blank
-	// var (
-	// 	perpDRow, perpDCol = dir.Offsets()
-	// 	perpI, perpJ       = i - perpDRow, j - perpDCol
-	// )
-
-	// if !board.HasTile(i+perpDRow, j+perpDCol) && !board.HasTile(i-perpDRow, j-perpDCol) {
-	// 	return true
-	// }
-
-	// for board.HasTile(perpI, perpJ) {
-	// 	perpI -= perpDRow
-	// 	perpJ -= perpDCol
-	// }
-	// // go back to the last tile I was on
-	// perpI += perpDRow
-	// perpJ += perpDCol
-
-	// // validate continuous string of tiles is a word
-	// wordRoot := s.searchSpace
-	// for {
-	// 	if board.HasTile(perpI, perpJ) {
-	// 		t := board.Cells[perpI][perpJ].Tile
-	// 		if !wordRoot.CanBranch(t) {
-	// 			// This is not a word, bail out
-	// 			return false
-	// 		}
-	// 		wordRoot = wordRoot.Branch(t)
-	// 	} else if perpI == i && perpJ == j {
-	// 		if !wordRoot.CanBranch(placed) {
-	// 			return false
-	// 		}
-	// 		wordRoot = wordRoot.Branch(placed)
-	// 	} else {
-	// 		break
-	// 	}
-	// 	perpI += perpDRow
-	// 	perpJ += perpDCol
-	// }
-
-	// // if so, recurse on search
-	// return wordRoot.IsTerminal()
-	return true
-}
@@ -1,6 +1,7 @@
 package ai_test
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"os"
@@ -32,31 +33,6 @@ func filter(scoredMoves []core.Turn, pred func(core.ScoredMove) bool) []core.Sco
 	return output
 }
 
-func normalize(board *core.Board, turns []core.Turn) []core.Turn {
-	output := []core.Turn{}
-	for i := range turns {
-		if m, ok := turns[i].(core.ScoredMove); ok {
-			output = append(output, core.ScoredMove{
-				PlacedTiles: board.NormalizeMove(m.PlacedTiles),
-				Score:       m.Score,
-			})
-		}
-	}
-	return output
-}
-
-func unique(turns []core.Turn) []core.Turn {
-	output := []core.Turn{}
-outer:
-	for _, m := range turns {
-		if contains(output, m) {
-			continue outer
-		}
-		output = append(output, m)
-	}
-	return output
-}
-
 func contains(turns []core.Turn, t core.Turn) bool {
 	for _, x := range turns {
 		if reflect.DeepEqual(x, t) {
@@ -66,11 +42,17 @@ func contains(turns []core.Turn, t core.Turn) bool {
 	return false
 }
 
-func intersection(a, b []core.Turn) []core.Turn {
+func intersection(board *core.Board, a, b []core.Turn) []core.Turn {
+	inB := map[core.MoveKey]bool{}
+	for _, t := range b {
+		if m, ok := t.(core.ScoredMove); ok {
+			inB[board.MoveKey(m.PlacedTiles)] = true
+		}
+	}
 	output := []core.Turn{}
-	for i := range a {
-		if contains(a, a[i]) && contains(b, a[i]) && (!contains(output, a[i])) {
-			output = append(output, a[i])
+	for _, t := range a {
+		if m, ok := t.(core.ScoredMove); ok && inB[board.MoveKey(m.PlacedTiles)] {
+			output = append(output, t)
 		}
 	}
 	return output
@@ -109,22 +91,7 @@ func compareSets(board *core.Board, aName, bName string, a, b []core.Turn) {
 	fmt.Printf("%s has %d valid\n", aName, len(filter(a, valid)))
 	fmt.Printf("%s has %d valid\n", bName, len(filter(b, valid)))
 
-	fmt.Printf("%s has %d unique\n", aName, len(unique(a)))
-	fmt.Printf("%s has %d unique\n", bName, len(unique(b)))
-
-	fmt.Printf("Intersection is %d\n", len(intersection(a, b)))
-
-	fmt.Printf("%s unique has %d vertical\n", aName, len(filter(unique(a), vertical)))
-	fmt.Printf("%s unique has %d vertical\n", bName, len(filter(unique(b), vertical)))
-
-	fmt.Printf("%s unique has %d horizontal\n", aName, len(filter(unique(a), horizontal)))
-	fmt.Printf("%s unique has %d horizontal\n", bName, len(filter(unique(b), horizontal)))
-
-	fmt.Printf("%s unique has %d withBlank\n", aName, len(filter(unique(a), withBlank)))
-	fmt.Printf("%s unique has %d withBlank\n", bName, len(filter(unique(b), withBlank)))
-
-	fmt.Printf("%s unique has %d valid\n", aName, len(filter(unique(a), valid)))
-	fmt.Printf("%s unique has %d valid\n", bName, len(filter(unique(b), valid)))
+	fmt.Printf("Intersection is %d\n", len(intersection(board, a, b)))
 }
 
 func dumpTurns(filename string, moves []core.Turn) {
@@ -150,7 +117,7 @@ func dumpTurns(filename string, moves []core.Turn) {
 
 func TestSpeedyMatchesSmarty(t *testing.T) {
 	tiles := core.NewConsumableRack(core.MakeTiles(core.MakeWord("asdjdha"), "xxxxxx "))
-	board := core.NewBoard()
+	board := core.NewBoard(core.ScrabbleLayout)
 
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("doggo"), "xxxxx"), 7, 7, core.Horizontal})
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("ar"), "xx"), 7, 8, core.Vertical})
@@ -161,20 +128,17 @@ func TestSpeedyMatchesSmarty(t *testing.T) {
 	defer smarty.Kill()
 
 	speedyMoves := []core.Turn{}
-	speedy.GenerateMoves(board, tiles, func(t core.Turn) bool {
+	speedy.GenerateMoves(context.Background(), board, tiles, func(t core.Turn) bool {
 		speedyMoves = append(speedyMoves, t)
 		return true
 	})
 
 	smartyMoves := []core.Turn{}
-	smarty.GenerateMoves(board, tiles, func(t core.Turn) bool {
+	smarty.GenerateMoves(context.Background(), board, tiles, func(t core.Turn) bool {
 		smartyMoves = append(smartyMoves, t)
 		return true
 	})
 
-	speedyMoves = unique(normalize(board, speedyMoves))
-	smartyMoves = unique(normalize(board, smartyMoves))
-
 	if !assert.Subset(t, speedyMoves, smartyMoves) {
 		fmt.Println("Missing moves:", filter(smartyMoves, func(m core.ScoredMove) bool {
 			return !contains(speedyMoves, m)
@@ -187,9 +151,9 @@ func TestSpeedyMatchesSmarty(t *testing.T) {
 
 func BenchmarkSpeedy(b *testing.B) {
 	tiles := core.NewConsumableRack(core.MakeTiles(core.MakeWord("bdhrigs"), "xxxxxx "))
-	board := core.NewBoard()
+	board := core.NewBoard(core.ScrabbleLayout)
 	speedy := ai.NewSpeedyAI(wordDB, wordGaddag)
-	bag := core.NewConsumableBag()
+	bag := core.NewConsumableBag(core.EnglishScrabble)
 	defer speedy.Kill()
 
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 0, 7, core.Vertical})
@@ -197,18 +161,19 @@ func BenchmarkSpeedy(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		speedy.FindMove(board, bag, tiles, func(core.Turn) bool { return true })
+		speedy.FindMove(context.Background(), board, bag, tiles, func(core.Turn) bool { return true })
 	}
 }
 
 func BenchmarkSpeedySearch(b *testing.B) {
 	rack := core.NewConsumableRack(core.MakeTiles(core.MakeWord("bdhrigs"), "xxxxxx "))
-	board := core.NewBoard()
+	board := core.NewBoard(core.ScrabbleLayout)
 	speedy := ai.NewSpeedyAI(wordDB, wordGaddag)
 	defer speedy.Kill()
 
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 0, 7, core.Vertical})
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("aaaaaaaaaaaaaa"), "xxxxxxxxxxxxxxx"), 7, 0, core.Horizontal})
+	board.EnableCrossChecks(wordDB)
 	prev := []core.Tile{}
 
 	b.ResetTimer()
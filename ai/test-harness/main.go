@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -43,7 +44,7 @@ func contains(l [][]core.Tile, e []core.Tile) bool {
 
 func main() {
 	tiles := core.NewConsumableRack(core.MakeTiles(core.MakeWord("asdjdha"), "xxxxxx "))
-	board := core.NewBoard()
+	board := core.NewBoard(core.ScrabbleLayout)
 
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("doggo"), "xxxxx"), 7, 7, core.Horizontal})
 	board.PlaceTiles(core.PlacedTiles{core.MakeTiles(core.MakeWord("ar"), "xx"), 7, 8, core.Vertical})
@@ -54,13 +55,13 @@ func main() {
 	defer speedy.Kill()
 
 	speedyMoves := []core.Turn{}
-	speedy.GenerateMoves(board, tiles, func(t core.Turn) bool {
+	speedy.GenerateMoves(context.Background(), board, tiles, func(t core.Turn) bool {
 		speedyMoves = append(speedyMoves, t)
 		return true
 	})
 
 	smartyMoves := []core.Turn{}
-	smarty.GenerateMoves(board, tiles, func(t core.Turn) bool {
+	smarty.GenerateMoves(context.Background(), board, tiles, func(t core.Turn) bool {
 		smartyMoves = append(smartyMoves, t)
 		return true
 	})
@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// Component is one named part of a move's value
+type Component struct {
+	Name  string
+	Value float64
+}
+
+// A ComponentEvaluator explains how it values a move with components which
+// add up to the value Evaluate returns.
+type ComponentEvaluator interface {
+	MoveEvaluator
+	Components(b *core.Board, rack core.Rack, move core.ScoredMove) []Component
+}
+
+// ScoreEvaluator values a move by the points it scores
+type ScoreEvaluator struct{}
+
+var _ ComponentEvaluator = ScoreEvaluator{}
+
+func (ScoreEvaluator) Evaluate(b *core.Board, rack core.Rack, move core.ScoredMove) float64 {
+	return float64(move.Score)
+}
+
+func (ScoreEvaluator) Components(b *core.Board, rack core.Rack, move core.ScoredMove) []Component {
+	return []Component{{Name: "score", Value: float64(move.Score)}}
+}
+
+// RankedMove is a move with its value. Leave holds the tiles the move keeps
+// on the rack.
+type RankedMove struct {
+	core.ScoredMove
+	Leave      []core.Tile
+	Value      float64
+	Components []Component
+}
+
+// TopMoves returns the n most valuable moves gen finds for rack, best first.
+// Moves of equal value are ordered by score and then by their MoveKey, so
+// the result doesn't depend on the order gen finds them in. Evaluators which
+// aren't ComponentEvaluators are explained by the move's score and an
+// "adjustment" for the rest of its value.
+func TopMoves(ctx context.Context, gen MoveGenerator, eval MoveEvaluator, b *core.Board, rack core.Rack, n int) []RankedMove {
+	if n <= 0 {
+		return nil
+	}
+	var top rankedHeap
+	gen.GenerateMoves(ctx, b, rack, func(turn core.Turn) bool {
+		move, ok := turn.(core.ScoredMove)
+		if !ok {
+			return true
+		}
+		c := candidate{
+			move:  move,
+			value: eval.Evaluate(b, rack, move),
+			key:   b.MoveKey(move.PlacedTiles),
+		}
+		if len(top) < n {
+			heap.Push(&top, c)
+		} else if c.before(top[0]) {
+			top[0] = c
+			heap.Fix(&top, 0)
+		}
+		return true
+	})
+
+	sort.Slice(top, func(i, j int) bool { return top[i].before(top[j]) })
+	ranked := make([]RankedMove, len(top))
+	for i, c := range top {
+		leave, _ := rack.Play(c.move.Word)
+		ranked[i] = RankedMove{
+			ScoredMove: c.move,
+			Leave:      leave.Rack,
+			Value:      c.value,
+			Components: components(eval, b, rack, c.move, c.value),
+		}
+	}
+	return ranked
+}
+
+func components(eval MoveEvaluator, b *core.Board, rack core.Rack, move core.ScoredMove, value float64) []Component {
+	if explained, ok := eval.(ComponentEvaluator); ok {
+		return explained.Components(b, rack, move)
+	}
+	parts := []Component{{Name: "score", Value: float64(move.Score)}}
+	if adjustment := value - float64(move.Score); adjustment != 0 {
+		parts = append(parts, Component{Name: "adjustment", Value: adjustment})
+	}
+	return parts
+}
+
+// candidate is a move being considered by TopMoves
+type candidate struct {
+	move  core.ScoredMove
+	value float64
+	key   core.MoveKey
+}
+
+// before reports whether c ranks above o
+func (c candidate) before(o candidate) bool {
+	switch {
+	case c.value != o.value:
+		return c.value > o.value
+	case c.move.Score != o.move.Score:
+		return c.move.Score > o.move.Score
+	case c.key.Row != o.key.Row:
+		return c.key.Row < o.key.Row
+	case c.key.Col != o.key.Col:
+		return c.key.Col < o.key.Col
+	case c.key.Direction != o.key.Direction:
+		return c.key.Direction == core.Horizontal
+	case c.key.Letters != o.key.Letters:
+		return c.key.Letters < o.key.Letters
+	}
+	return c.key.Blanks < o.key.Blanks
+}
+
+// rankedHeap keeps the worst candidate on top, so it can be replaced
+type rankedHeap []candidate
+
+func (h rankedHeap) Len() int            { return len(h) }
+func (h rankedHeap) Less(i, j int) bool  { return h[j].before(h[i]) }
+func (h rankedHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *rankedHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *rankedHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
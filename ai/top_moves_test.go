@@ -0,0 +1,71 @@
+package ai_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// keepS values keeping an s on the rack at 8 points
+type keepS struct{}
+
+func (keepS) Evaluate(b *core.Board, rack core.Rack, move core.ScoredMove) float64 {
+	for _, t := range move.Word {
+		if t.ToRune() == 's' {
+			return float64(move.Score)
+		}
+	}
+	return float64(move.Score) + 8
+}
+
+func TestTopMovesByScore(t *testing.T) {
+	rack := core.NewConsumableRack(tiles("retains"))
+	var scores []int
+	for _, move := range collectMoves(core.NewBoard(core.ScrabbleLayout), rack, ai.NewAnchorAI(wordDB, wordDB)) {
+		scores = append(scores, int(core.NewBoard(core.ScrabbleLayout).Score(move)))
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+
+	top := ai.TopMoves(context.Background(), ai.NewAnchorAI(wordDB, wordDB), ai.ScoreEvaluator{}, core.NewBoard(core.ScrabbleLayout), rack, 5)
+	if assert.Len(t, top, 5) {
+		for i, move := range top {
+			assert.Equal(t, scores[i], int(move.Score))
+			assert.Equal(t, float64(move.Score), move.Value)
+			assert.Equal(t, []ai.Component{{Name: "score", Value: move.Value}}, move.Components)
+			assert.Len(t, move.Leave, 7-len(move.Word))
+		}
+	}
+
+	smarty := ai.NewSmartyAI(wordDB, wordDB)
+	defer smarty.Kill()
+	assert.Equal(t, top, ai.TopMoves(context.Background(), smarty, ai.ScoreEvaluator{}, core.NewBoard(core.ScrabbleLayout), rack, 5), "ties were broken by generation order")
+}
+
+func TestTopMovesByEvaluator(t *testing.T) {
+	rack := core.NewConsumableRack(tiles("aestvwz"))
+	top := ai.TopMoves(context.Background(), ai.NewAnchorAI(wordDB, wordDB), keepS{}, core.NewBoard(core.ScrabbleLayout), rack, 20)
+	assert.Len(t, top, 20)
+	keptS := 0
+	for i, move := range top {
+		if i > 0 {
+			assert.True(t, top[i-1].Value >= move.Value, "moves are out of order")
+		}
+		var sum float64
+		for _, c := range move.Components {
+			sum += c.Value
+		}
+		assert.Equal(t, move.Value, sum)
+		if len(move.Components) > 1 {
+			assert.Equal(t, ai.Component{Name: "adjustment", Value: 8}, move.Components[1])
+			keptS++
+		}
+	}
+	assert.NotZero(t, keptS)
+
+	assert.Empty(t, ai.TopMoves(context.Background(), ai.NewAnchorAI(wordDB, wordDB), keepS{}, core.NewBoard(core.ScrabbleLayout), rack, 0))
+}
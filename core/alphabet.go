@@ -0,0 +1,193 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxLetters is the largest number of letters an Alphabet may contain
+const MaxLetters = 32
+
+// Alphabet maps the letters of a language onto compact Letter indices. A
+// letter may be written with more than one rune, like the Spanish "ch" tile.
+type Alphabet struct {
+	Name    string
+	letters []string
+	index   map[string]Letter
+	fold    map[rune]string
+	longest int
+}
+
+// English is the plain a-z alphabet used by Rune2Letter and MakeWord
+var English = NewAlphabet("english", nil, strings.Split("abcdefghijklmnopqrstuvwxyz", "")...)
+
+// French plays with a-z tiles, accents are dropped when reading words
+var French = NewAlphabet("french", map[rune]string{
+	'à': "a", 'â': "a", 'ä': "a", 'ç': "c", 'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'î': "i", 'ï': "i", 'ô': "o", 'ö': "o", 'ù': "u", 'û': "u", 'ü': "u", 'ÿ': "y",
+	'œ': "oe", 'æ': "ae",
+}, strings.Split("abcdefghijklmnopqrstuvwxyz", "")...)
+
+// Spanish has digraph tiles for CH, LL and RR, an Ñ tile and no K or W
+var Spanish = NewAlphabet("spanish", map[rune]string{
+	'á': "a", 'é': "e", 'í': "i", 'ó': "o", 'ú': "u", 'ü': "u",
+}, "a", "b", "c", "ch", "d", "e", "f", "g", "h", "i", "j", "l", "ll", "m",
+	"n", "ñ", "o", "p", "q", "r", "rr", "s", "t", "u", "v", "x", "y", "z")
+
+// German adds umlauted vowels to a-z
+var German = NewAlphabet("german", map[rune]string{
+	'ß': "ss",
+}, append(strings.Split("abcdefghijklmnopqrstuvwxyz", ""), "ä", "ö", "ü")...)
+
+// LookupAlphabet returns the built in alphabet with the given name
+func LookupAlphabet(name string) (*Alphabet, bool) {
+	for _, a := range []*Alphabet{English, French, Spanish, German} {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// NewAlphabet creates an alphabet whose letters are numbered in the order
+// given. fold rewrites runes that are not tiles of their own, like accented
+// vowels, before a word is split into letters.
+func NewAlphabet(name string, fold map[rune]string, letters ...string) *Alphabet {
+	if len(letters) > MaxLetters {
+		panic(fmt.Sprintf("alphabet %q has %d letters, at most %d are supported", name, len(letters), MaxLetters))
+	}
+	a := &Alphabet{
+		Name:    name,
+		letters: letters,
+		index:   make(map[string]Letter, len(letters)),
+		fold:    fold,
+	}
+	for i, l := range letters {
+		a.index[l] = Letter(i)
+		if n := utf8.RuneCountInString(l); n > a.longest {
+			a.longest = n
+		}
+	}
+	return a
+}
+
+// Size returns the number of letters in the alphabet
+func (a *Alphabet) Size() int {
+	return len(a.letters)
+}
+
+// Letters returns every letter in the alphabet in order
+func (a *Alphabet) Letters() []Letter {
+	output := make([]Letter, len(a.letters))
+	for i := range output {
+		output[i] = Letter(i)
+	}
+	return output
+}
+
+// Parse splits a word into letters, preferring the longest letter at each
+// position so that "chico" is read as CH-I-C-O in Spanish.
+func (a *Alphabet) Parse(word string) (Word, error) {
+	runes := []rune(a.normalize(word))
+	output := make(Word, 0, len(runes))
+outer:
+	for i := 0; i < len(runes); {
+		for n := a.longest; n > 0; n-- {
+			if i+n > len(runes) {
+				continue
+			}
+			if l, ok := a.index[string(runes[i:i+n])]; ok {
+				output = append(output, l)
+				i += n
+				continue outer
+			}
+		}
+		return nil, fmt.Errorf("%q is not in the %s alphabet (in %q)", runes[i], a.Name, word)
+	}
+	return output, nil
+}
+
+// MustParse is like Parse but panics if the word cannot be parsed
+func (a *Alphabet) MustParse(word string) Word {
+	output, err := a.Parse(word)
+	if err != nil {
+		panic(err)
+	}
+	return output
+}
+
+func (a *Alphabet) normalize(word string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if folded, ok := a.fold[r]; ok {
+			sb.WriteString(folded)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// LetterString returns the lowercase text of a letter
+func (a *Alphabet) LetterString(l Letter) string {
+	if int(l) < 0 || int(l) >= len(a.letters) {
+		return "?"
+	}
+	return a.letters[l]
+}
+
+// WordString joins the letters of a word into text
+func (a *Alphabet) WordString(word Word) string {
+	var sb strings.Builder
+	for _, l := range word {
+		sb.WriteString(a.LetterString(l))
+	}
+	return sb.String()
+}
+
+// TileString renders a tile the same way Tile.String does, with blanks in uppercase
+func (a *Alphabet) TileString(t Tile) string {
+	s := a.LetterString(t.ToLetter())
+	if t.IsBlank() {
+		return strings.Map(unicode.ToUpper, s)
+	}
+	return s
+}
+
+// TilesString renders tiles with TileString
+func (a *Alphabet) TilesString(tiles []Tile) string {
+	var sb strings.Builder
+	for _, t := range tiles {
+		sb.WriteString(a.TileString(t))
+	}
+	return sb.String()
+}
+
+// ParseTiles reads tiles written by TilesString, where uppercase letters are
+// blanks
+func (a *Alphabet) ParseTiles(text string) ([]Tile, error) {
+	runes := []rune(text)
+	output := make([]Tile, 0, len(runes))
+outer:
+	for i := 0; i < len(runes); {
+		for n := a.longest; n > 0; n-- {
+			if i+n > len(runes) {
+				continue
+			}
+			s := string(runes[i : i+n])
+			lower := strings.ToLower(s)
+			if s != lower && s != strings.ToUpper(s) {
+				continue
+			}
+			if l, ok := a.index[lower]; ok {
+				output = append(output, l.ToTile(s != lower))
+				i += n
+				continue outer
+			}
+		}
+		return nil, fmt.Errorf("%q is not in the %s alphabet (in %q)", runes[i], a.Name, text)
+	}
+	return output, nil
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlphabetParseDigraphs(t *testing.T) {
+	word, err := Spanish.Parse("Chorro")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, word, 4)
+	assert.Equal(t, "ch", Spanish.LetterString(word[0]))
+	assert.Equal(t, "rr", Spanish.LetterString(word[2]))
+	assert.Equal(t, "chorro", Spanish.WordString(word))
+}
+
+func TestAlphabetParseFolding(t *testing.T) {
+	assert.Equal(t, French.MustParse("ete"), French.MustParse("été"))
+	assert.Equal(t, "strasse", German.WordString(German.MustParse("Straße")))
+	assert.Equal(t, "ñ", Spanish.LetterString(Spanish.MustParse("año")[1]))
+	assert.Equal(t, "ö", German.LetterString(German.MustParse("öl")[0]))
+}
+
+func TestAlphabetParseErrors(t *testing.T) {
+	_, err := Spanish.Parse("kiwi")
+	assert.Error(t, err)
+	_, err = English.Parse("año")
+	assert.Error(t, err)
+}
+
+func TestAlphabetMatchesEnglishHelpers(t *testing.T) {
+	assert.Equal(t, MakeWord("quiz"), English.MustParse("quiz"))
+	tiles := MakeTiles(MakeWord("quiz"), "x xx")
+	assert.Equal(t, Tiles2String(tiles), English.TilesString(tiles))
+	assert.Equal(t, "CH", Spanish.TileString(Spanish.MustParse("ch")[0].ToTile(true)))
+}
+
+func TestAlphabetParseTiles(t *testing.T) {
+	word := Spanish.MustParse("llañ")
+	tiles := []Tile{word[0].ToTile(true), word[1].ToTile(false), word[2].ToTile(true)}
+	text := Spanish.TilesString(tiles)
+	assert.Equal(t, "LLaÑ", text)
+	parsed, err := Spanish.ParseTiles(text)
+	assert.NoError(t, err)
+	assert.Equal(t, tiles, parsed)
+
+	parsed, err = English.ParseTiles("qUiz")
+	assert.NoError(t, err)
+	assert.Equal(t, String2Tiles("qUiz"), parsed)
+
+	_, err = German.ParseTiles("{")
+	assert.Error(t, err)
+}
+
+func TestForeignTileSets(t *testing.T) {
+	assert.Equal(t, 102, FrenchScrabble.Size())
+	assert.Equal(t, 100, SpanishScrabble.Size())
+	assert.Equal(t, 102, GermanScrabble.Size())
+
+	ll := Spanish.MustParse("ll")[0].ToTile(false)
+	assert.Equal(t, Score(8), SpanishScrabble.PointValue(ll))
+	assert.Equal(t, 100, NewConsumableBag(SpanishScrabble).Count())
+}
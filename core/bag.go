@@ -4,31 +4,32 @@ import (
 	"fmt"
 	"math/bits"
 	"math/rand"
-	"strings"
 )
 
-// A-9, B-2, C-2, D-4, E-12, F-2, G-3, H-2, I-9, J-1, K-1, L-4, M-2, N-6, O-8, P-2, Q-1, R-6, S-4, T-6, U-4, V-2, W-2, X-1, Y-2, Z-1 and Blanks-2.
-
-const allLetters = "aaaaaaaaabbccddddeeeeeeeeeeeeffggghhiiiiiiiiijkllllmmnnnnnnooooooooppqrrrrrrssssttttttuuuuvvwwxyyz"
-
-var allTiles = MakeTiles(MakeWord(allLetters+"aa"), strings.Repeat("x", len(allLetters))+"  ")
-
 type Bag struct {
+	set      *TileSet
 	tiles    []Tile
-	consumed [2]uint64
+	consumed [4]uint64
 }
 
-func NewConsumableBag() Bag {
-	allTilesCopy := make([]Tile, len(allTiles))
-	copy(allTilesCopy, allTiles)
+// NewConsumableBag creates a full bag containing every tile in the set
+func NewConsumableBag(set *TileSet) Bag {
+	allTilesCopy := make([]Tile, len(set.tiles))
+	copy(allTilesCopy, set.tiles)
 	b := Bag{
+		set:   set,
 		tiles: allTilesCopy,
 	}
 	return b
 }
 
+// TileSet returns the set of tiles the bag was filled from
+func (c Bag) TileSet() *TileSet {
+	return c.set
+}
+
 func (c Bag) validate() {
-	if c.getBit(len(allTiles) + 1) {
+	if c.getBit(len(c.set.tiles) + 1) {
 		panic("Invalid bag")
 	}
 }
@@ -51,12 +52,14 @@ func (c Bag) Shuffle() Bag {
 		if r := recover(); r != nil {
 			fmt.Printf("%b -> %b\n", c.consumed[0], result.consumed[0])
 			fmt.Printf("%b -> %b\n", c.consumed[1], result.consumed[1])
+			fmt.Printf("%b -> %b\n", c.consumed[2], result.consumed[2])
+			fmt.Printf("%b -> %b\n", c.consumed[3], result.consumed[3])
 			panic(r)
 		}
 	}()
 	c.validate()
-	result.tiles = make([]Tile, len(allTiles))
-	copy(result.tiles, allTiles)
+	result.tiles = make([]Tile, len(c.tiles))
+	copy(result.tiles, c.tiles)
 	for i := len(result.tiles) - 1; i > 0; i-- {
 		j := rand.Intn(i)
 		result.tiles[i], result.tiles[j] = result.tiles[j], result.tiles[i]
@@ -135,10 +138,19 @@ func (c Bag) Replace(tiles []Tile) Bag {
 	return c
 }
 
+// CanExchange returns true if the bag holds enough tiles to allow an
+// exchange, which is at least a full rack.
+func (c Bag) CanExchange() bool {
+	return c.Count() >= c.TileSet().RackSize
+}
+
 func (c Bag) Count() int {
 	c.validate()
-	consumed := bits.OnesCount64(c.consumed[0]) + bits.OnesCount64(c.consumed[1])
-	return len(allTiles) - consumed
+	consumed := 0
+	for _, field := range c.consumed {
+		consumed += bits.OnesCount64(field)
+	}
+	return len(c.set.tiles) - consumed
 }
 
 func (c *Bag) setBit(i int) {
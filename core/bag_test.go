@@ -10,8 +10,8 @@ import (
 
 func TestConsumableBagCanConsume(t *testing.T) {
 	err := quick.Check(func(i byte) bool {
-		idx := int(i) % len(allTiles)
-		originalBag := NewConsumableBag()
+		idx := int(i) % EnglishScrabble.Size()
+		originalBag := NewConsumableBag(EnglishScrabble)
 		changedBag := originalBag.Consume(idx)
 		return assert.True(t, originalBag.CanConsume(idx), "New Bag should be able to consume tile %d", idx) &&
 			assert.False(t, changedBag.CanConsume(idx), "Changed bag must not be able to consume tile %d", idx) &&
@@ -22,34 +22,34 @@ func TestConsumableBagCanConsume(t *testing.T) {
 
 func TestConsumableBagNumTiles(t *testing.T) {
 	err := quick.Check(func(i byte) bool {
-		idx := int(i) % len(allTiles)
-		originalBag := NewConsumableBag()
+		idx := int(i) % EnglishScrabble.Size()
+		originalBag := NewConsumableBag(EnglishScrabble)
 		for i := 0; i < idx; i++ {
 			originalBag = originalBag.Consume(i)
 		}
-		return assert.Equal(t, len(allTiles)-idx, originalBag.Count(), "Consuming %d tiles should leave %d in the bag", idx, len(allTiles)-idx)
+		return assert.Equal(t, EnglishScrabble.Size()-idx, originalBag.Count(), "Consuming %d tiles should leave %d in the bag", idx, EnglishScrabble.Size()-idx)
 	}, nil)
 	assert.NoError(t, err)
 }
 
 func TestConsumableBagFillRack(t *testing.T) {
 	err := quick.Check(func(i byte) bool {
-		idx := int(i) % len(allTiles)
-		originalBag := NewConsumableBag()
+		idx := int(i) % EnglishScrabble.Size()
+		originalBag := NewConsumableBag(EnglishScrabble)
 		rack := NewConsumableRack(nil)
 		originalBag, rack.Rack = originalBag.FillRack(rack.Rack, idx)
-		return assert.Equal(t, len(allTiles)-idx, originalBag.Count(), "Filling a rack with %d tiles should leave %d in the bag", idx, len(allTiles)-idx)
+		return assert.Equal(t, EnglishScrabble.Size()-idx, originalBag.Count(), "Filling a rack with %d tiles should leave %d in the bag", idx, EnglishScrabble.Size()-idx)
 	}, nil)
 	assert.NoError(t, err)
 }
 
 func TestConsumableBagShuffling(t *testing.T) {
 	err := quick.Check(func(i byte) bool {
-		idx := int(i) % len(allTiles)
+		idx := int(i) % EnglishScrabble.Size()
 		if idx <= 10 {
 			idx = 10
 		}
-		originalBag := NewConsumableBag()
+		originalBag := NewConsumableBag(EnglishScrabble)
 		shuffledBag := originalBag.Shuffle()
 
 		originalRack := NewConsumableRack(nil)
@@ -67,3 +67,17 @@ func TestConsumableBagShuffling(t *testing.T) {
 	}, nil)
 	assert.NoError(t, err)
 }
+
+func TestConsumableBagReshuffling(t *testing.T) {
+	err := quick.Check(func(i byte) bool {
+		idx := int(i) % EnglishScrabble.Size()
+		bag := NewConsumableBag(EnglishScrabble).Shuffle()
+		bag, _ = bag.FillRack(nil, idx)
+		unseen := bag.Remaining()
+
+		reshuffled := bag.Shuffle()
+		return assert.Equal(t, bag.Count(), reshuffled.Count()) &&
+			assert.ElementsMatch(t, unseen, reshuffled.Remaining(), "Shuffling a drawn bag changed which tiles are left")
+	}, nil)
+	assert.NoError(t, err)
+}
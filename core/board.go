@@ -62,6 +62,19 @@ func (d Direction) String() string {
 	return "Vertical"
 }
 
+// Scan reads a direction saved to a database, which may store it as an integer
+func (d *Direction) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case bool:
+		*d = Direction(v)
+	case int64:
+		*d = Direction(v != 0)
+	default:
+		return fmt.Errorf("can't scan %T into a Direction", value)
+	}
+	return nil
+}
+
 // Offsets returns a direction vector for moving in the direction
 func (d Direction) Offsets() (dRow, dCol int) {
 	if d == Horizontal {
@@ -70,107 +83,130 @@ func (d Direction) Offsets() (dRow, dCol int) {
 	return 1, 0
 }
 
-// Cell represents a spot on the board
-type Cell struct {
-	Bonus Bonus
-	Tile  Tile
-}
-
-// Board is a regular scrabble board
+// Board is a scrabble board with the shape described by its Layout. Moves
+// are scored with TileSet, which defaults to EnglishScrabble. Placed moves
+// are remembered so they can be taken back with UnplaceTiles.
+//
+// Tiles are packed into a fixed size array, bonuses are read from the
+// layout, so boards can be copied with CopyFrom without allocating.
 type Board struct {
-	Cells [15][15]Cell
+	Layout              *BoardLayout
+	TileSet             *TileSet
 	StoreValidatedMoves bool
-	ValidatedMoves []PlacedTiles
+	ValidatedMoves      []PlacedTiles
+
+	// squares holds the complement of the tile on each square in row major
+	// order, so the zero value is an empty square
+	squares [MaxSquares]uint16
+	hash    uint64
+
+	history []placement
+	placed  []int
+	redo    []PlacedTiles
+	cross   *crossChecks
 }
 
-// NewBoard initializes an empty board
-func NewBoard() *Board {
-	b := new(Board)
-	for i, row := range b.Cells {
-		for j := range row {
-			b.Cells[i][j].Tile = -1
-			b.Cells[i][j].Bonus = normalBonus[i][j]
-		}
+// NewBoard initializes an empty board with the given layout
+func NewBoard(layout *BoardLayout) *Board {
+	if layout.Rows*layout.Cols > MaxSquares {
+		panic(fmt.Sprintf("layout %q has %d squares, at most %d are supported", layout.Name, layout.Rows*layout.Cols, MaxSquares))
+	}
+	return &Board{
+		Layout:         layout,
+		TileSet:        EnglishScrabble,
+		ValidatedMoves: []PlacedTiles{},
 	}
-	b.ValidatedMoves = []PlacedTiles{}
-	return b
 }
 
+// Clone returns a copy of the board
 func (b *Board) Clone() *Board {
-	output := new(Board)
-	for i, row := range b.Cells {
-		for j := range row {
-			output.Cells[i][j] = b.Cells[i][j]
-		}
-	}
+	output := &Board{}
+	output.CopyFrom(b)
 	return output
 }
 
-// Save encodes a representation of the board to the given file
-func (b *Board) Save(filename string) error {
-	f, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0660)
-	if err != nil {
-		return err
+// CopyFrom makes b a copy of other, reusing b's memory. Once b has copied a
+// board of similar history it does not allocate.
+func (b *Board) CopyFrom(other *Board) {
+	b.Layout = other.Layout
+	b.TileSet = other.TileSet
+	b.squares = other.squares
+	b.hash = other.hash
+	b.history = append(b.history[:0], other.history...)
+	b.placed = append(b.placed[:0], other.placed...)
+	b.redo = append(b.redo[:0], other.redo...)
+	if other.cross == nil {
+		b.cross = nil
+		return
 	}
-	defer f.Close()
-	return json.NewEncoder(f).Encode(b)
+	if b.cross == nil {
+		b.cross = new(crossChecks)
+	}
+	b.cross.copyFrom(other.cross)
 }
 
-// HasTile returns true if the given spot is occupied
-func (b *Board) HasTile(row, col int) bool {
+// Equal returns true if both boards have the same layout and the same tiles
+// in the same places, regardless of the order they were placed in.
+func (b *Board) Equal(other *Board) bool {
+	return b.Layout == other.Layout && b.squares == other.squares
+}
+
+// TileAt returns the tile at the given square, or a tile for which IsNoTile
+// is true if the square is empty or off the board.
+func (b *Board) TileAt(row, col int) Tile {
 	if b.OutOfBounds(row, col) {
-		return false
+		return -1
 	}
-	return !b.Cells[row][col].Tile.IsNoTile()
+	return unpackTile(b.squares[row*b.Layout.Cols+col])
 }
 
-// ValidateMove returns true if the given move is legal
-func (b *Board) ValidateMove(move PlacedTiles, wordList WordList) bool {
-	if b.StoreValidatedMoves {
-		b.ValidatedMoves = append(b.ValidatedMoves, move)
+func unpackTile(square uint16) Tile {
+	if square == 0 {
+		return -1
 	}
-	// Check that it connects to other words
-	connectsToOtherWords := false
-	dRow, dCol := move.Direction.Offsets()
-	wordPos := 0
-	for progress := 0; wordPos < len(move.Word); progress++ {
-		tileRow := move.Row + dRow*progress
-		tileCol := move.Col + dCol*progress
+	return Tile(^square)
+}
 
-		if b.OutOfBounds(tileRow, tileCol) {
-			return false
-		}
-		if !b.HasTile(tileRow, tileCol) {
-			wordPos++
-		}
+// BonusAt returns the bonus printed on the given square
+func (b *Board) BonusAt(row, col int) Bonus {
+	return b.Layout.Bonuses[row][col]
+}
 
-		if !connectsToOtherWords {
-			if tileRow == 7 && tileCol == 7 {
-				connectsToOtherWords = true
-			} else if b.HasTile(tileRow-1, tileCol) ||
-				b.HasTile(tileRow+1, tileCol) ||
-				b.HasTile(tileRow, tileCol-1) ||
-				b.HasTile(tileRow, tileCol+1) {
-				connectsToOtherWords = true
-			}
-		}
-	}
+func (b *Board) setTile(square int, t Tile) {
+	b.hash ^= zobristKey(square, unpackTile(b.squares[square]))
+	b.squares[square] = ^uint16(t)
+	b.hash ^= zobristKey(square, t)
+}
 
-	if !connectsToOtherWords {
-		return false
+// Save encodes the board's layout and moves to the given file
+func (b *Board) Save(filename string) error {
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0660)
+	if err != nil {
+		return err
 	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(struct {
+		Layout *BoardLayout
+		Moves  []PlacedTiles
+	}{b.Layout, b.Moves()})
+}
 
-	words := b.FindNewWords(move)
-	for _, word := range words {
-		if !wordList.Contains(tiles2Word(word.Word)) {
-			return false
-		}
-	}
-	return true
+// HasTile returns true if the given spot is occupied
+func (b *Board) HasTile(row, col int) bool {
+	return !b.TileAt(row, col).IsNoTile()
+}
+
+// ValidateMove returns true if the given move is legal. Use
+// ValidateMoveDetailed to find out why a move is illegal.
+func (b *Board) ValidateMove(move PlacedTiles, wordList WordList) bool {
+	return b.ValidateMoveDetailed(move, wordList) == nil
 }
 
 // Score computes the score for a given move. Score does not validate the move.
 func (b *Board) Score(move PlacedTiles) Score {
+	if b.cross != nil {
+		return b.scoreWithCrossChecks(move)
+	}
 	words := b.FindNewWords(move)
 
 	defer func() {
@@ -188,8 +224,9 @@ func (b *Board) Score(move PlacedTiles) Score {
 	return total
 }
 
+// OutOfBounds returns true if the given spot is not on the board
 func (b *Board) OutOfBounds(row, col int) bool {
-	return row < 0 || row >= 15 || col < 0 || col >= 15
+	return row < 0 || row >= b.Layout.Rows || col < 0 || col >= b.Layout.Cols
 }
 
 func (b *Board) scoreWord(move PlacedTiles) Score {
@@ -206,7 +243,7 @@ func (b *Board) scoreWord(move PlacedTiles) Score {
 		}
 		letterBonus := Bonus(1)
 		if !b.HasTile(tileRow, tileCol) {
-			bonus := b.Cells[tileRow][tileCol].Bonus
+			bonus := b.BonusAt(tileRow, tileCol)
 			switch bonus {
 			case DoubleLetter:
 				letterBonus *= 2
@@ -219,10 +256,10 @@ func (b *Board) scoreWord(move PlacedTiles) Score {
 			}
 			lettersUsed++
 		}
-		sum += letter.PointValue() * letterBonus
+		sum += letter.PointValue(b.TileSet) * letterBonus
 	}
-	if lettersUsed >= 7 {
-		additionalBonus = 50
+	if lettersUsed >= b.TileSet.RackSize {
+		additionalBonus = b.TileSet.BingoBonus
 	}
 	return sum*wordBonus + additionalBonus
 }
@@ -244,7 +281,7 @@ func (b *Board) FindNewWords(move PlacedTiles) []PlacedTiles {
 	for !b.OutOfBounds(tileRow, tileCol) && wordPos < len(move.Word) {
 
 		if b.HasTile(tileRow, tileCol) {
-			letters = append(letters, b.Cells[tileRow][tileCol].Tile)
+			letters = append(letters, b.TileAt(tileRow, tileCol))
 		} else {
 			subWord, ok := b.GrowWord(move.Word[wordPos], tileRow, tileCol, !move.Direction)
 			if ok {
@@ -297,6 +334,13 @@ func (b *Board) GrowWord(l Tile, row, col int, dir Direction) (PlacedTiles, bool
 
 // PlaceTiles places tiles. It does not validate the move.
 func (b *Board) PlaceTiles(move PlacedTiles) []Tile {
+	b.redo = b.redo[:0]
+	return b.placeTiles(move)
+}
+
+func (b *Board) placeTiles(move PlacedTiles) []Tile {
+	start := len(b.placed)
+	b.history = append(b.history, placement{move: move, start: start})
 	dRow, dCol := move.Direction.Offsets()
 	progress := 0
 	wordPos := 0
@@ -309,11 +353,14 @@ func (b *Board) PlaceTiles(move PlacedTiles) []Tile {
 		}
 
 		if !b.HasTile(tileRow, tileCol) {
-			b.Cells[tileRow][tileCol].Tile = move.Word[wordPos]
+			square := tileRow*b.Layout.Cols + tileCol
+			b.setTile(square, move.Word[wordPos])
+			b.placed = append(b.placed, square)
 			wordPos++
 		}
 		progress++
 	}
+	b.updateCrossChecks(b.placed[start:])
 	return move.Word
 }
 
@@ -334,15 +381,15 @@ func (b *Board) NormalizeMove(move PlacedTiles) PlacedTiles {
 
 // Print prints the board to the console
 func (b *Board) Print() {
-	for i, row := range b.Cells {
-		for j, cell := range row {
+	for i := 0; i < b.Layout.Rows; i++ {
+		for j := 0; j < b.Layout.Cols; j++ {
 			letter := " "
 			cellColor := color.New(color.FgBlack)
 			if b.HasTile(i, j) {
-				letter = cell.Tile.String()
+				letter = b.TileAt(i, j).String()
 				cellColor = cellColor.Add(color.BgMagenta)
 			} else {
-				cellColor = addBonusColor(cell, cellColor)
+				cellColor = addBonusColor(b.BonusAt(i, j), cellColor)
 			}
 
 			cellColor.Printf(" %s ", letter)
@@ -351,8 +398,8 @@ func (b *Board) Print() {
 	}
 }
 
-func addBonusColor(cell Cell, cellColor *color.Color) *color.Color {
-	switch cell.Bonus {
+func addBonusColor(bonus Bonus, cellColor *color.Color) *color.Color {
+	switch bonus {
 	case DoubleWord:
 		cellColor = cellColor.Add(color.BgCyan)
 	case TripleWord:
@@ -369,7 +416,7 @@ func addBonusColor(cell Cell, cellColor *color.Color) *color.Color {
 
 func (b *Board) scan(letters []Tile, row, col, dRow, dCol int) []Tile {
 	for b.HasTile(row, col) {
-		letters = append(letters, b.Cells[row][col].Tile)
+		letters = append(letters, b.TileAt(row, col))
 		row += dRow
 		col += dCol
 	}
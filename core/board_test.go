@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileAt(t *testing.T) {
+	b := NewBoard(ScrabbleLayout)
+	assert.True(t, b.TileAt(7, 7).IsNoTile())
+	assert.True(t, b.TileAt(-1, 7).IsNoTile())
+	assert.True(t, b.TileAt(7, 15).IsNoTile())
+
+	blank := Rune2Letter('q').ToTile(true).SetFlag(7, true)
+	b.PlaceTiles(PlacedTiles{Word: []Tile{blank}, Row: 7, Col: 7, Direction: Horizontal})
+	assert.Equal(t, blank, b.TileAt(7, 7))
+}
+
+func TestHashIgnoresMoveOrder(t *testing.T) {
+	cat := PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal}
+	at := PlacedTiles{Word: toTiles("ar"), Row: 8, Col: 8, Direction: Vertical}
+
+	a := NewBoard(ScrabbleLayout)
+	a.PlaceTiles(cat)
+	a.PlaceTiles(at)
+
+	b := NewBoard(ScrabbleLayout)
+	b.PlaceTiles(at)
+	b.PlaceTiles(cat)
+
+	assert.True(t, a.Equal(b))
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.NotZero(t, a.Hash())
+
+	c := NewBoard(ScrabbleLayout)
+	c.PlaceTiles(PlacedTiles{Word: MakeTiles(MakeWord("cat"), "x x"), Row: 7, Col: 7, Direction: Horizontal})
+	c.PlaceTiles(at)
+	assert.False(t, a.Equal(c))
+	assert.NotEqual(t, a.Hash(), c.Hash(), "blanks hash differently")
+}
+
+func TestCopyFrom(t *testing.T) {
+	b := NewBoard(ScrabbleLayout)
+	b.EnableCrossChecks(wordSet{"cat": true, "cats": true})
+	b.PlaceTiles(PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal})
+
+	copied := NewBoard(WordsWithFriendsLayout)
+	copied.CopyFrom(b)
+	assert.True(t, copied.Equal(b))
+	assert.Equal(t, b.Hash(), copied.Hash())
+	assert.Equal(t, b.Anchors(), copied.Anchors())
+
+	copied.PlaceTiles(PlacedTiles{Word: toTiles("s"), Row: 7, Col: 10, Direction: Horizontal})
+	assert.False(t, b.HasTile(7, 10))
+	assert.False(t, copied.Equal(b))
+	assert.True(t, b.CrossCheck(7, 10, Vertical).Contains(Rune2Letter('s')))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		copied.CopyFrom(b)
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkCopyFrom(b *testing.B) {
+	board := NewBoard(ScrabbleLayout)
+	board.PlaceTiles(PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal})
+	copied := NewBoard(ScrabbleLayout)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		copied.CopyFrom(board)
+	}
+}
+
+func TestDirectionScan(t *testing.T) {
+	var d Direction
+	assert.NoError(t, d.Scan(int64(1)))
+	assert.Equal(t, Vertical, d)
+	assert.NoError(t, d.Scan(false))
+	assert.Equal(t, Horizontal, d)
+	assert.Error(t, d.Scan("vertical"))
+}
@@ -41,3 +41,21 @@ var normalBonus = [...][15]Bonus{
 	{xx, DW, xx, xx, xx, TL, xx, xx, xx, TL, xx, xx, xx, DW, xx},
 	{TW, xx, xx, DL, xx, xx, xx, TW, xx, xx, xx, DL, xx, xx, TW},
 }
+
+var wordsWithFriendsBonus = [...][15]Bonus{
+	{xx, xx, xx, TW, xx, xx, TL, xx, TL, xx, xx, TW, xx, xx, xx},
+	{xx, xx, DL, xx, xx, DW, xx, xx, xx, DW, xx, xx, DL, xx, xx},
+	{xx, DL, xx, xx, DL, xx, xx, xx, xx, xx, DL, xx, xx, DL, xx},
+	{TW, xx, xx, TL, xx, xx, xx, DW, xx, xx, xx, TL, xx, xx, TW},
+	{xx, xx, DL, xx, xx, xx, DL, xx, DL, xx, xx, xx, DL, xx, xx},
+	{xx, DW, xx, xx, xx, TL, xx, xx, xx, TL, xx, xx, xx, DW, xx},
+	{TL, xx, xx, xx, DL, xx, xx, xx, xx, xx, DL, xx, xx, xx, TL},
+	{xx, xx, xx, DW, xx, xx, xx, xx, xx, xx, xx, DW, xx, xx, xx},
+	{TL, xx, xx, xx, DL, xx, xx, xx, xx, xx, DL, xx, xx, xx, TL},
+	{xx, DW, xx, xx, xx, TL, xx, xx, xx, TL, xx, xx, xx, DW, xx},
+	{xx, xx, DL, xx, xx, xx, DL, xx, DL, xx, xx, xx, DL, xx, xx},
+	{TW, xx, xx, TL, xx, xx, xx, DW, xx, xx, xx, TL, xx, xx, TW},
+	{xx, DL, xx, xx, DL, xx, xx, xx, xx, xx, DL, xx, xx, DL, xx},
+	{xx, xx, DL, xx, xx, DW, xx, xx, xx, DW, xx, xx, DL, xx, xx},
+	{xx, xx, xx, TW, xx, xx, TL, xx, TL, xx, xx, TW, xx, xx, xx},
+}
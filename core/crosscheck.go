@@ -0,0 +1,252 @@
+package core
+
+import "reflect"
+
+// LetterSet is a set of letters, one bit per Letter
+type LetterSet uint64
+
+// AllLetters contains every letter
+const AllLetters = ^LetterSet(0)
+
+// Contains returns true if the letter is in the set
+func (s LetterSet) Contains(l Letter) bool {
+	return s&(1<<uint(l)) != 0
+}
+
+// crossChecks caches, for every square, which letters form valid words
+// perpendicular to a move in each direction and what those words score
+// before the new tile is added. It is kept up to date by PlaceTiles and
+// UnplaceTiles once EnableCrossChecks has been called.
+type crossChecks struct {
+	wordList WordList
+	checks   [2][]LetterSet
+	scores   [2][]Score
+	anchor   []bool
+	anchors  []Square
+}
+
+// Square is a position on the board
+type Square struct {
+	Row, Col int
+}
+
+func dirIndex(dir Direction) int {
+	if dir == Horizontal {
+		return 0
+	}
+	return 1
+}
+
+// EnableCrossChecks computes cross-checks and anchors against the given word
+// list and keeps them up to date as tiles are placed and taken back. It does
+// nothing if they are already enabled for the same word list.
+func (b *Board) EnableCrossChecks(wordList WordList) {
+	if b.HasCrossChecks(wordList) {
+		return
+	}
+	n := b.Layout.Rows * b.Layout.Cols
+	c := &crossChecks{
+		wordList: wordList,
+		anchor:   make([]bool, n),
+	}
+	for d := range c.checks {
+		c.checks[d] = make([]LetterSet, n)
+		c.scores[d] = make([]Score, n)
+	}
+	b.cross = c
+	for i := 0; i < b.Layout.Rows; i++ {
+		for j := 0; j < b.Layout.Cols; j++ {
+			b.updateSquare(i, j)
+		}
+	}
+	b.updateAnchors()
+}
+
+// HasCrossChecks returns true if cross-checks are enabled for wordList. Word
+// lists are told apart by pointer, so one which isn't a pointer, like a map,
+// never matches and its cross-checks are always recomputed.
+func (b *Board) HasCrossChecks(wordList WordList) bool {
+	return b.cross != nil && sameWordList(b.cross.wordList, wordList)
+}
+
+func sameWordList(a, b WordList) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	return va.Kind() == reflect.Ptr && vb.Kind() == reflect.Ptr &&
+		va.Type() == vb.Type() && va.Pointer() == vb.Pointer()
+}
+
+// WithCrossChecks returns b if its cross-checks are enabled for wordList, or
+// else a copy of b with them enabled. b is never changed, so move generators
+// can use it on boards other goroutines are reading. Callers that search the
+// same board repeatedly should enable cross-checks on it themselves.
+func (b *Board) WithCrossChecks(wordList WordList) *Board {
+	if b.HasCrossChecks(wordList) {
+		return b
+	}
+	board := new(Board)
+	board.CopyFrom(b)
+	board.EnableCrossChecks(wordList)
+	return board
+}
+
+// CrossCheck returns the letters which can be placed at row, col by a move in
+// dir without forming an invalid word in the other direction. Cross-checks
+// must be enabled.
+func (b *Board) CrossCheck(row, col int, dir Direction) LetterSet {
+	return b.cross.checks[dirIndex(dir)][row*b.Layout.Cols+col]
+}
+
+// CrossScore returns the value of the tiles a move in dir would join up with
+// in the other direction by placing a tile at row, col, before any bonuses.
+// Cross-checks must be enabled.
+func (b *Board) CrossScore(row, col int, dir Direction) Score {
+	return b.cross.scores[dirIndex(dir)][row*b.Layout.Cols+col]
+}
+
+// Anchors returns the empty squares every move must cover at least one of:
+// squares next to a tile, or the start square. Cross-checks must be enabled.
+func (b *Board) Anchors() []Square {
+	return b.cross.anchors
+}
+
+// IsAnchor returns true if row, col is one of Anchors. Cross-checks must be enabled.
+func (b *Board) IsAnchor(row, col int) bool {
+	return !b.OutOfBounds(row, col) && b.cross.anchor[row*b.Layout.Cols+col]
+}
+
+// updateCrossChecks refreshes the squares affected by changing the given squares
+func (b *Board) updateCrossChecks(squares []int) {
+	if b.cross == nil {
+		return
+	}
+	for _, square := range squares {
+		row, col := square/b.Layout.Cols, square%b.Layout.Cols
+		b.updateSquare(row, col)
+		for _, dir := range []Direction{Horizontal, Vertical} {
+			dRow, dCol := dir.Offsets()
+			for _, sign := range []int{-1, 1} {
+				i, j := row+sign*dRow, col+sign*dCol
+				for b.HasTile(i, j) {
+					i += sign * dRow
+					j += sign * dCol
+				}
+				if !b.OutOfBounds(i, j) {
+					b.updateSquare(i, j)
+				}
+			}
+		}
+	}
+	b.updateAnchors()
+}
+
+func (b *Board) updateSquare(row, col int) {
+	square := row*b.Layout.Cols + col
+	b.cross.anchor[square] = !b.HasTile(row, col) && (b.Layout.IsStart(row, col) ||
+		b.HasTile(row-1, col) || b.HasTile(row+1, col) ||
+		b.HasTile(row, col-1) || b.HasTile(row, col+1))
+
+	for _, dir := range []Direction{Horizontal, Vertical} {
+		d := dirIndex(dir)
+		if b.HasTile(row, col) {
+			b.cross.checks[d][square] = 0
+			b.cross.scores[d][square] = 0
+			continue
+		}
+		word, ok := b.GrowWord(Letter(0).ToTile(false), row, col, !dir)
+		if !ok {
+			b.cross.checks[d][square] = AllLetters
+			b.cross.scores[d][square] = 0
+			continue
+		}
+
+		// word has a placeholder where the new tile goes
+		dRow, dCol := (!dir).Offsets()
+		pos := (row-word.Row)*dRow + (col-word.Col)*dCol
+		letters := Tiles2Word(word.Word)
+		set := LetterSet(0)
+		for l := 0; l < b.TileSet.Alphabet.Size(); l++ {
+			letters[pos] = Letter(l)
+			if b.cross.wordList.Contains(letters) {
+				set |= 1 << uint(l)
+			}
+		}
+		b.cross.checks[d][square] = set
+		word.Word[pos] = Letter(0).ToTile(true)
+		b.cross.scores[d][square] = b.TileSet.Sum(word.Word)
+	}
+}
+
+// scoreWithCrossChecks scores a move using the cached cross scores instead
+// of finding every new word, which saves allocating them. It gives the same
+// result as Score.
+func (b *Board) scoreWithCrossChecks(move PlacedTiles) Score {
+	dRow, dCol := move.Direction.Offsets()
+	d := dirIndex(move.Direction)
+
+	// tiles already on the board before the move joins the main word
+	row, col := move.Row-dRow, move.Col-dCol
+	mainWord := Score(0)
+	for b.HasTile(row, col) {
+		mainWord += b.TileSet.PointValue(b.TileAt(row, col))
+		row -= dRow
+		col -= dCol
+	}
+
+	wordBonus := Bonus(1)
+	crossWords := Score(0)
+	row, col = move.Row, move.Col
+	placed := 0
+	for !b.OutOfBounds(row, col) && (placed < len(move.Word) || b.HasTile(row, col)) {
+		if b.HasTile(row, col) {
+			mainWord += b.TileSet.PointValue(b.TileAt(row, col))
+		} else {
+			square := row*b.Layout.Cols + col
+			value := b.TileSet.PointValue(move.Word[placed])
+			letterBonus, squareWordBonus := Bonus(1), Bonus(1)
+			switch b.BonusAt(row, col) {
+			case DoubleLetter:
+				letterBonus = 2
+			case TripleLetter:
+				letterBonus = 3
+			case DoubleWord:
+				squareWordBonus = 2
+			case TripleWord:
+				squareWordBonus = 3
+			}
+			mainWord += value * letterBonus
+			wordBonus *= squareWordBonus
+			if b.cross.checks[d][square] != AllLetters {
+				crossWords += (b.cross.scores[d][square] + value*letterBonus) * squareWordBonus
+			}
+			placed++
+		}
+		row += dRow
+		col += dCol
+	}
+
+	total := mainWord*wordBonus + crossWords
+	if placed >= b.TileSet.RackSize {
+		total += b.TileSet.BingoBonus
+	}
+	return total
+}
+
+func (b *Board) updateAnchors() {
+	anchors := make([]Square, 0, len(b.cross.anchors))
+	for square, anchor := range b.cross.anchor {
+		if anchor {
+			anchors = append(anchors, Square{square / b.Layout.Cols, square % b.Layout.Cols})
+		}
+	}
+	b.cross.anchors = anchors
+}
+
+func (c *crossChecks) copyFrom(other *crossChecks) {
+	c.wordList = other.wordList
+	c.anchor = append(c.anchor[:0], other.anchor...)
+	c.anchors = other.anchors
+	for d := range c.checks {
+		c.checks[d] = append(c.checks[d][:0], other.checks[d]...)
+		c.scores[d] = append(c.scores[d][:0], other.scores[d]...)
+	}
+}
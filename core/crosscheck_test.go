@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type wordSet map[string]bool
+
+func (w wordSet) Contains(word Word) bool {
+	return w[English.WordString(word)]
+}
+
+func newCrossCheckBoard() *Board {
+	b := NewBoard(ScrabbleLayout)
+	b.PlaceTiles(PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal})
+	b.PlaceTiles(PlacedTiles{Word: toTiles("ar"), Row: 8, Col: 8, Direction: Vertical})
+	return b
+}
+
+func lettersOf(set LetterSet) string {
+	var output []Letter
+	for l := Letter(0); l < 26; l++ {
+		if set.Contains(l) {
+			output = append(output, l)
+		}
+	}
+	return English.WordString(output)
+}
+
+func TestCrossChecks(t *testing.T) {
+	b := newCrossCheckBoard()
+	b.EnableCrossChecks(wordSet{"cat": true, "cats": true, "scat": true, "at": true, "ta": true, "aa": true})
+
+	// above the a of cat the column reads ?aar, which is never a word
+	assert.Equal(t, "", lettersOf(b.CrossCheck(6, 8, Horizontal)))
+	// after cat: cats
+	assert.Equal(t, "s", lettersOf(b.CrossCheck(7, 10, Vertical)))
+	// before cat: scat
+	assert.Equal(t, "s", lettersOf(b.CrossCheck(7, 6, Vertical)))
+	// above the t: ?t
+	assert.Equal(t, "a", lettersOf(b.CrossCheck(6, 9, Horizontal)))
+	// nothing nearby
+	assert.Equal(t, AllLetters, b.CrossCheck(0, 0, Horizontal))
+	assert.Equal(t, LetterSet(0), b.CrossCheck(7, 7, Horizontal))
+
+	// c-3 a-1 t-1
+	assert.Equal(t, Score(5), b.CrossScore(7, 10, Vertical))
+	assert.Equal(t, Score(1), b.CrossScore(6, 9, Horizontal))
+}
+
+func TestCrossChecksFollowUndo(t *testing.T) {
+	words := wordSet{"cat": true, "cats": true}
+	b := NewBoard(ScrabbleLayout)
+	b.EnableCrossChecks(words)
+	assert.Equal(t, []Square{{7, 7}}, b.Anchors())
+
+	b.PlaceTiles(PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal})
+	assert.Equal(t, "s", lettersOf(b.CrossCheck(7, 10, Vertical)))
+	assert.Len(t, b.Anchors(), 8)
+
+	b.UnplaceTiles()
+	fresh := NewBoard(ScrabbleLayout)
+	fresh.EnableCrossChecks(words)
+	assert.Equal(t, fresh.cross, b.cross)
+}
+
+func TestScoreWithCrossChecksMatchesScore(t *testing.T) {
+	b := newCrossCheckBoard()
+	b.PlaceTiles(PlacedTiles{Word: toTiles("zoned"), Row: 9, Col: 4, Direction: Horizontal})
+	fast := b.Clone()
+	fast.EnableCrossChecks(wordSet{})
+
+	for _, word := range []string{"q", "qi", "jab", "exams", "quartzy", "oxyphenbutazone"} {
+		for i := 0; i < b.Layout.Rows; i++ {
+			for j := 0; j < b.Layout.Cols; j++ {
+				for _, dir := range []Direction{Horizontal, Vertical} {
+					move := PlacedTiles{Word: toTiles(word), Row: i, Col: j, Direction: dir}
+					if b.ValidateMoveDetailed(move, wordSet{}).(*InvalidMoveError).Reason != InvalidWords {
+						continue
+					}
+					assert.Equal(t, b.Score(move), fast.Score(move), "scoring %s", move)
+				}
+			}
+		}
+	}
+}
+
+func TestCrossChecksForUncomparableWordLists(t *testing.T) {
+	b := newCrossCheckBoard()
+	words := wordSet{"cat": true, "cats": true}
+	b.EnableCrossChecks(words)
+	b.EnableCrossChecks(words)
+	assert.False(t, b.HasCrossChecks(words), "maps can't be told apart")
+	assert.Equal(t, "s", lettersOf(b.CrossCheck(7, 10, Vertical)))
+
+	copied := b.WithCrossChecks(wordSet{"cat": true, "scat": true})
+	assert.Equal(t, "s", lettersOf(copied.CrossCheck(7, 6, Vertical)))
+	assert.Equal(t, "", lettersOf(b.CrossCheck(7, 6, Vertical)), "the board was changed")
+}
+
+type wordPtr struct{ wordSet }
+
+func TestWithCrossChecks(t *testing.T) {
+	b := newCrossCheckBoard()
+	words := &wordPtr{wordSet{"cat": true, "cats": true}}
+	copied := b.WithCrossChecks(words)
+	assert.True(t, copied != b)
+	assert.Nil(t, b.cross, "the board was changed")
+	assert.True(t, copied.HasCrossChecks(words))
+	assert.False(t, copied.HasCrossChecks(&wordPtr{words.wordSet}))
+
+	b.EnableCrossChecks(words)
+	assert.True(t, b.WithCrossChecks(words) == b)
+}
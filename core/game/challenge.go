@@ -0,0 +1,122 @@
+package game
+
+import (
+	"errors"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// ChallengeRule decides whether phonies may be played and what happens when
+// a move is challenged
+type ChallengeRule int
+
+// Supported challenge rules
+const (
+	// VoidChallenge rejects moves with invalid words outright, so there is
+	// nothing to challenge.
+	VoidChallenge ChallengeRule = iota
+	// DoubleChallenge lets phonies be played. A successful challenge
+	// withdraws the move, a failed one costs the challenger their turn.
+	DoubleChallenge
+	// SingleChallenge is like DoubleChallenge, except a failed challenge
+	// awards ChallengePenalty points to the challenged player instead.
+	SingleChallenge
+)
+
+// ChallengePenalty is awarded to the challenged player after a failed single challenge
+const ChallengePenalty core.Score = 5
+
+// ErrNothingToChallenge is returned when a challenge does not match the last move played
+var ErrNothingToChallenge = errors.New("there is no move to challenge")
+
+// undo is what is needed to withdraw the last move after a successful challenge
+type undo struct {
+	event          int
+	words          []core.PlacedTiles
+	bag            core.Bag
+	rack           core.Rack
+	scores         []core.Score
+	scorelessTurns int
+	adjustments    int
+}
+
+func (s *State) saveUndo(words []core.PlacedTiles) {
+	u := &undo{
+		event:          len(s.History),
+		words:          words,
+		bag:            s.Bag,
+		rack:           s.CurrentPlayer().Rack,
+		scorelessTurns: s.ScorelessTurns,
+		adjustments:    len(s.Adjustments),
+	}
+	for _, p := range s.Players {
+		u.scores = append(u.scores, p.Score)
+	}
+	s.undo = u
+}
+
+// CanChallenge returns true if the last move may still be challenged. This
+// remains true after a player goes out, until the challenge is made or the
+// next turn is played.
+func (s *State) CanChallenge() bool {
+	return s.undo != nil
+}
+
+// ChallengeableWords returns the words formed by the move which may be challenged
+func (s *State) ChallengeableWords() []core.PlacedTiles {
+	if s.undo == nil {
+		return nil
+	}
+	return s.undo.words
+}
+
+// ChallengeLastMove returns the turn which challenges the move that may be challenged
+func (s *State) ChallengeLastMove() core.ChallengeWord {
+	if s.undo == nil {
+		return core.ChallengeWord{}
+	}
+	return core.ChallengeWord{Move: s.History[s.undo.event].Turn.(core.ScoredMove).PlacedTiles}
+}
+
+// challenge resolves a challenge of the last move by the current player
+func (s *State) challenge(c core.ChallengeWord) (Event, error) {
+	u := s.undo
+	last := s.History[u.event]
+	move := last.Turn.(core.ScoredMove)
+	if c.Move.Row != move.Row || c.Move.Col != move.Col || c.Move.Direction != move.Direction {
+		return Event{}, ErrNothingToChallenge
+	}
+	s.undo = nil
+
+	event := Event{Player: s.Current, Turn: c}
+	s.Board.UnplaceTiles()
+	if s.Board.ValidateMoveDetailed(move.PlacedTiles, s.WordList) != nil {
+		s.Bag = u.bag
+		s.Players[last.Player].Rack = u.rack
+		for i, score := range u.scores {
+			s.Players[i].Score = score
+		}
+		s.Adjustments = s.Adjustments[:u.adjustments]
+		s.EndReason = NotOver
+		s.History[u.event].Withdrawn = true
+		s.History = append(s.History, event)
+		// the withdrawn move was a scoreless turn
+		s.ScorelessTurns = u.scorelessTurns
+		s.countScoreless(0)
+		return event, nil
+	}
+
+	s.Board.Redo()
+	s.History = append(s.History, event)
+	if s.Rule == SingleChallenge {
+		event.Awarded = ChallengePenalty
+		s.History[len(s.History)-1] = event
+		s.Players[last.Player].Score += ChallengePenalty
+		return event, nil
+	}
+	if !s.IsOver() {
+		s.countScoreless(0)
+		s.Current = (s.Current + 1) % len(s.Players)
+	}
+	return event, nil
+}
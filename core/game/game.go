@@ -0,0 +1,281 @@
+// Package game enforces the rules of a game of scrabble: whose turn it is,
+// drawing tiles, keeping score and deciding when the game is over.
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// MaxScorelessTurns is the number of consecutive turns without a score which ends the game
+const MaxScorelessTurns = 6
+
+// ErrGameOver is returned when a turn is played after the game has ended
+var ErrGameOver = errors.New("the game is over")
+
+// EndReason explains why a game ended
+type EndReason int
+
+// Ways a game can end
+const (
+	NotOver EndReason = iota
+	PlayerWentOut
+	TooManyScorelessTurns
+)
+
+// Player is a seat at the table
+type Player struct {
+	Name  string
+	Rack  core.Rack
+	Score core.Score
+}
+
+// Event is a single turn in the history of a game
+type Event struct {
+	Player int
+	Turn   core.Turn
+	Score  core.Score
+	// Rack is the player's rack before the turn, Leave is what remained
+	// before drawing new tiles.
+	Rack, Leave []core.Tile
+	// Withdrawn is set on moves taken back after a successful challenge
+	Withdrawn bool
+	// Awarded is the number of points given to the challenged player by a
+	// failed single challenge
+	Awarded core.Score
+}
+
+// Adjustment is a change to a player's score for the tiles left on racks at the end of the game
+type Adjustment struct {
+	Player int
+	Rack   []core.Tile
+	Score  core.Score
+}
+
+// State is a game in progress
+type State struct {
+	// WordList checks the words formed by each move. A nil WordList accepts every word.
+	WordList       core.WordList
+	Rule           ChallengeRule
+	Board          *core.Board
+	Bag            core.Bag
+	Players        []*Player
+	Current        int
+	History        []Event
+	ScorelessTurns int
+	EndReason      EndReason
+	Adjustments    []Adjustment
+
+	undo *undo
+}
+
+// New starts a game on the given board, dealing a rack from the bag to each named player
+func New(wordList core.WordList, board *core.Board, bag core.Bag, names ...string) *State {
+	s := &State{
+		WordList: wordList,
+		Board:    board,
+		Bag:      bag,
+	}
+	for _, name := range names {
+		p := &Player{Name: name, Rack: core.NewConsumableRack(nil)}
+		s.Bag, p.Rack.Rack = s.Bag.FillRack(p.Rack.Rack, s.rackSize())
+		s.Players = append(s.Players, p)
+	}
+	return s
+}
+
+// Clone returns a copy of the game which can be played independently
+func (s *State) Clone() *State {
+	output := *s
+	output.Board = s.Board.Clone()
+	output.Players = make([]*Player, len(s.Players))
+	for i, p := range s.Players {
+		player := *p
+		output.Players[i] = &player
+	}
+	output.History = append([]Event(nil), s.History...)
+	output.Adjustments = append([]Adjustment(nil), s.Adjustments...)
+	if s.undo != nil {
+		u := *s.undo
+		output.undo = &u
+	}
+	return &output
+}
+
+// CurrentPlayer returns the player whose turn it is
+func (s *State) CurrentPlayer() *Player {
+	return s.Players[s.Current]
+}
+
+// IsOver returns true once the game has ended
+func (s *State) IsOver() bool {
+	return s.EndReason != NotOver
+}
+
+// Play takes a turn for the current player. Illegal turns are rejected with
+// an error and leave the game unchanged. Under DoubleChallenge and
+// SingleChallenge, moves forming invalid words are accepted and the next
+// player may answer with a core.ChallengeWord before taking their turn.
+func (s *State) Play(turn core.Turn) (Event, error) {
+	if c, ok := turn.(core.ChallengeWord); ok {
+		if s.undo == nil || s.WordList == nil {
+			return Event{}, ErrNothingToChallenge
+		}
+		return s.challenge(c)
+	}
+	if s.IsOver() {
+		return Event{}, ErrGameOver
+	}
+	p := s.CurrentPlayer()
+	event := Event{Player: s.Current, Turn: turn, Rack: p.Rack.Rack, Leave: p.Rack.Rack}
+
+	switch t := turn.(type) {
+	case core.ScoredMove:
+		if s.WordList != nil {
+			if err := s.Board.ValidateMoveDetailed(t.PlacedTiles, s.WordList); err != nil && !s.allowsPhony(err) {
+				return Event{}, err
+			}
+		}
+		if err := p.Rack.ValidatePlay(t.PlacedTiles); err != nil {
+			return Event{}, err
+		}
+		if s.Rule != VoidChallenge && s.WordList != nil {
+			s.saveUndo(s.Board.FindNewWords(t.PlacedTiles))
+		} else {
+			s.undo = nil
+		}
+		leave, _ := p.Rack.Play(t.Word)
+		event.Leave = leave.Rack
+		event.Score = s.Board.Score(t.PlacedTiles)
+		t.Score = event.Score
+		event.Turn = t
+		s.Board.PlaceTiles(t.PlacedTiles)
+
+		p.Rack = leave
+		s.Bag, p.Rack.Rack = s.Bag.FillRack(p.Rack.Rack, s.rackSize()-len(p.Rack.Rack))
+		p.Score += event.Score
+	case core.Pass:
+		s.undo = nil
+	case core.Exchange:
+		if err := p.Rack.ValidateExchange(t, s.Bag); err != nil {
+			return Event{}, err
+		}
+		s.undo = nil
+		leave, _ := p.Rack.Play(t.Tiles)
+		event.Leave = leave.Rack
+
+		// draw before returning tiles so the same tiles can't be drawn back
+		p.Rack = leave
+		s.Bag, p.Rack.Rack = s.Bag.FillRack(p.Rack.Rack, s.rackSize()-len(p.Rack.Rack))
+		s.Bag = s.Bag.Replace(t.Tiles)
+	default:
+		return Event{}, fmt.Errorf("%s cannot play unknown turn type %T", p.Name, turn)
+	}
+
+	s.History = append(s.History, event)
+	if _, ok := turn.(core.ScoredMove); ok && len(p.Rack.Rack) == 0 && s.Bag.Count() == 0 {
+		s.end(PlayerWentOut, s.Current)
+	} else {
+		s.countScoreless(event.Score)
+	}
+	s.Current = (s.Current + 1) % len(s.Players)
+	return event, nil
+}
+
+// Record applies a move whose tiles came from a rack the game does not know
+// about, as when replaying a game entered by hand. The tiles are taken
+// straight from the bag and the move is placed and scored even if it is
+// illegal, in which case the reason is returned alongside the event.
+func (s *State) Record(move core.PlacedTiles) (Event, error) {
+	var err error
+	if s.WordList != nil {
+		err = s.Board.ValidateMoveDetailed(move, s.WordList)
+	}
+	event := Event{
+		Player: s.Current,
+		Score:  s.Board.Score(move),
+		Rack:   move.Word,
+	}
+	event.Turn = core.ScoredMove{PlacedTiles: move, Score: event.Score}
+	s.undo = nil
+	s.Board.PlaceTiles(move)
+	s.Bag = s.Bag.ConsumeTiles(move.Word)
+
+	s.History = append(s.History, event)
+	if len(s.Players) > 0 {
+		s.CurrentPlayer().Score += event.Score
+		s.countScoreless(event.Score)
+		s.Current = (s.Current + 1) % len(s.Players)
+	}
+	return event, err
+}
+
+// allowsPhony returns true if a move rejected with err may be played
+// anyway and left to the opponent to challenge
+func (s *State) allowsPhony(err error) bool {
+	moveErr, ok := err.(*core.InvalidMoveError)
+	return ok && moveErr.Reason == core.InvalidWords && s.Rule != VoidChallenge
+}
+
+func (s *State) countScoreless(score core.Score) {
+	if score != 0 {
+		s.ScorelessTurns = 0
+		return
+	}
+	s.ScorelessTurns++
+	if s.ScorelessTurns >= MaxScorelessTurns {
+		s.end(TooManyScorelessTurns, -1)
+	}
+}
+
+// end finishes the game and adjusts scores for the tiles left on each rack.
+// Every player loses the value of their own rack and, if a player went out,
+// they gain the total value of everyone else's rack.
+func (s *State) end(reason EndReason, wentOut int) {
+	s.EndReason = reason
+	tiles := s.Bag.TileSet()
+	bonus := core.Score(0)
+	for i, p := range s.Players {
+		if i == wentOut {
+			continue
+		}
+		value := tiles.Sum(p.Rack.Rack)
+		bonus += value
+		s.adjust(i, -value)
+	}
+	if wentOut >= 0 {
+		s.adjust(wentOut, bonus)
+	}
+}
+
+func (s *State) adjust(player int, score core.Score) {
+	p := s.Players[player]
+	p.Score += score
+	s.Adjustments = append(s.Adjustments, Adjustment{
+		Player: player,
+		Rack:   p.Rack.Rack,
+		Score:  score,
+	})
+}
+
+// Winner returns the index of the player with the highest score, or -1 for a tie
+func (s *State) Winner() int {
+	winner, best, tied := -1, core.Score(0), false
+	for i, p := range s.Players {
+		if winner == -1 || p.Score > best {
+			winner, best, tied = i, p.Score, false
+		} else if p.Score == best {
+			tied = true
+		}
+	}
+	if tied {
+		return -1
+	}
+	return winner
+}
+
+func (s *State) rackSize() int {
+	return s.Bag.TileSet().RackSize
+}
@@ -0,0 +1,255 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/stretchr/testify/assert"
+)
+
+type wordSet map[string]bool
+
+func (w wordSet) Contains(word core.Word) bool {
+	return w[core.English.WordString(word)]
+}
+
+func toTiles(word string) []core.Tile {
+	return core.MakeTiles(core.MakeWord(word), strings.Repeat("x", len(word)))
+}
+
+func play(word string, row, col int, dir core.Direction) core.ScoredMove {
+	return core.ScoredMove{PlacedTiles: core.PlacedTiles{Word: toTiles(word), Row: row, Col: col, Direction: dir}}
+}
+
+// newEndgame returns a game with an empty bag and the given racks
+func newEndgame(racks ...string) *State {
+	bag := core.NewConsumableBag(core.EnglishScrabble)
+	bag = bag.ConsumeTiles(bag.Remaining())
+	s := New(nil, core.NewBoard(core.ScrabbleLayout), bag)
+	for i, rack := range racks {
+		s.Players = append(s.Players, &Player{Name: string('a' + rune(i)), Rack: core.NewConsumableRack(toTiles(rack))})
+	}
+	return s
+}
+
+func TestNewDealsRacks(t *testing.T) {
+	s := New(nil, core.NewBoard(core.ScrabbleLayout), core.NewConsumableBag(core.EnglishScrabble).Shuffle(), "a", "b")
+	assert.Len(t, s.Players, 2)
+	assert.Len(t, s.Players[0].Rack.Rack, 7)
+	assert.Len(t, s.Players[1].Rack.Rack, 7)
+	assert.Equal(t, 86, s.Bag.Count())
+	assert.Equal(t, 0, s.Current)
+}
+
+func TestPlayScoresAndRefills(t *testing.T) {
+	bag := core.NewConsumableBag(core.EnglishScrabble)
+	bag = bag.ConsumeTiles(toTiles("catsdog"))
+	s := New(wordSet{"cat": true}, core.NewBoard(core.ScrabbleLayout), bag)
+	s.Players = []*Player{
+		{Name: "a", Rack: core.NewConsumableRack(toTiles("catsdog"))},
+		{Name: "b", Rack: core.NewConsumableRack(nil)},
+	}
+
+	event, err := s.Play(play("cat", 7, 7, core.Horizontal))
+	assert.NoError(t, err)
+	assert.Equal(t, core.Score(10), event.Score)
+	assert.Equal(t, core.Score(10), s.Players[0].Score)
+	assert.ElementsMatch(t, toTiles("sdog"), event.Leave)
+	assert.Len(t, s.Players[0].Rack.Rack, 7)
+	assert.Equal(t, 1, s.Current)
+	assert.Len(t, s.History, 1)
+}
+
+func TestPlayRejectsInvalidMoves(t *testing.T) {
+	s := newEndgame("cat", "dog")
+	s.WordList = wordSet{"cat": true, "dog": true}
+
+	_, err := s.Play(play("dog", 7, 7, core.Horizontal))
+	assert.Error(t, err)
+	_, err = s.Play(play("tac", 7, 7, core.Horizontal))
+	assert.Error(t, err)
+
+	assert.Equal(t, 0, s.Current)
+	assert.Empty(t, s.History)
+	assert.True(t, s.Board.IsEmpty())
+}
+
+func TestGoingOut(t *testing.T) {
+	s := newEndgame("cat", "dogz")
+
+	_, err := s.Play(play("cat", 7, 7, core.Horizontal))
+	assert.NoError(t, err)
+	assert.True(t, s.IsOver())
+	assert.Equal(t, PlayerWentOut, s.EndReason)
+
+	// d-2 o-1 g-2 z-10
+	assert.Equal(t, core.Score(10+15), s.Players[0].Score)
+	assert.Equal(t, core.Score(-15), s.Players[1].Score)
+	assert.Equal(t, 0, s.Winner())
+
+	_, err = s.Play(core.Pass{})
+	assert.Equal(t, ErrGameOver, err)
+}
+
+func TestSixScorelessTurns(t *testing.T) {
+	s := newEndgame("cat", "dogz")
+
+	for i := 0; i < MaxScorelessTurns-1; i++ {
+		_, err := s.Play(core.Pass{})
+		assert.NoError(t, err)
+		assert.False(t, s.IsOver())
+	}
+	_, err := s.Play(core.Pass{})
+	assert.NoError(t, err)
+	assert.Equal(t, TooManyScorelessTurns, s.EndReason)
+	assert.Equal(t, core.Score(-5), s.Players[0].Score)
+	assert.Equal(t, core.Score(-15), s.Players[1].Score)
+	assert.Len(t, s.Adjustments, 2)
+}
+
+func TestScoringResetsScorelessTurns(t *testing.T) {
+	s := newEndgame("catsdog", "dogz")
+
+	for i := 0; i < MaxScorelessTurns-1; i++ {
+		s.Play(core.Pass{})
+	}
+	_, err := s.Play(play("dog", 7, 7, core.Horizontal))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.ScorelessTurns)
+	assert.False(t, s.IsOver())
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	s := newEndgame("cat", "dog")
+	clone := s.Clone()
+	_, err := clone.Play(play("cat", 7, 7, core.Horizontal))
+	assert.NoError(t, err)
+
+	assert.True(t, s.Board.IsEmpty())
+	assert.Equal(t, core.Score(0), s.Players[0].Score)
+	assert.Empty(t, s.History)
+	assert.False(t, s.IsOver())
+}
+
+func TestRecord(t *testing.T) {
+	s := New(wordSet{"cat": true}, core.NewBoard(core.ScrabbleLayout), core.NewConsumableBag(core.EnglishScrabble))
+	event, err := s.Record(play("cat", 7, 7, core.Horizontal).PlacedTiles)
+	assert.NoError(t, err)
+	assert.Equal(t, core.Score(10), event.Score)
+
+	_, err = s.Record(play("dog", 0, 0, core.Horizontal).PlacedTiles)
+	assert.Error(t, err)
+	assert.Equal(t, 94, s.Bag.Count())
+	assert.True(t, s.Board.HasTile(0, 0))
+}
+
+func TestExchange(t *testing.T) {
+	s := New(nil, core.NewBoard(core.ScrabbleLayout), core.NewConsumableBag(core.EnglishScrabble).ConsumeTiles(toTiles("qqatzzi")))
+	s.Players = []*Player{
+		{Name: "a", Rack: core.NewConsumableRack(toTiles("qatziou"))},
+		{Name: "b", Rack: core.NewConsumableRack(nil)},
+	}
+	count := s.Bag.Count()
+
+	event, err := s.Play(core.Exchange{Tiles: toTiles("qz")})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, toTiles("atiou"), event.Leave)
+	assert.Len(t, s.Players[0].Rack.Rack, 7)
+	assert.Equal(t, count, s.Bag.Count())
+	assert.Equal(t, 1, s.ScorelessTurns)
+
+	_, err = s.Play(core.Exchange{Tiles: toTiles("q")})
+	assert.Error(t, err)
+}
+
+func TestExchangeNeedsFullRackInBag(t *testing.T) {
+	s := newEndgame("cat", "dog")
+	s.Bag = s.Bag.Replace(toTiles("abcdef"))
+	assert.Equal(t, 6, s.Bag.Count())
+
+	_, err := s.Play(core.Exchange{Tiles: toTiles("c")})
+	assert.Error(t, err)
+	assert.Equal(t, 0, s.Current)
+}
+
+func newChallengeGame(rule ChallengeRule) *State {
+	s := newEndgame("zatcd", "dog")
+	s.Bag = s.Bag.Replace(toTiles("eeeeeeeeee"))
+	s.WordList = wordSet{"cat": true, "dog": true}
+	s.Rule = rule
+	return s
+}
+
+func TestVoidChallengeRejectsPhonies(t *testing.T) {
+	s := newChallengeGame(VoidChallenge)
+	_, err := s.Play(play("zat", 7, 7, core.Horizontal))
+	assert.Error(t, err)
+	assert.False(t, s.CanChallenge())
+}
+
+func TestSuccessfulChallenge(t *testing.T) {
+	s := newChallengeGame(DoubleChallenge)
+	bag := s.Bag
+
+	_, err := s.Play(play("zat", 7, 7, core.Horizontal))
+	assert.NoError(t, err)
+	assert.True(t, s.CanChallenge())
+	assert.Equal(t, core.Score(24), s.Players[0].Score)
+
+	_, err = s.Play(s.ChallengeLastMove())
+	assert.NoError(t, err)
+	assert.True(t, s.Board.IsEmpty())
+	assert.Equal(t, core.Score(0), s.Players[0].Score)
+	assert.ElementsMatch(t, toTiles("zatcd"), s.Players[0].Rack.Rack)
+	assert.Equal(t, bag, s.Bag)
+	assert.True(t, s.History[0].Withdrawn)
+	assert.Equal(t, 1, s.Current, "the challenger still takes their turn")
+	assert.Equal(t, 1, s.ScorelessTurns)
+	assert.False(t, s.CanChallenge())
+}
+
+func TestFailedDoubleChallenge(t *testing.T) {
+	s := newChallengeGame(DoubleChallenge)
+	s.Play(play("cat", 7, 7, core.Horizontal))
+
+	_, err := s.Play(s.ChallengeLastMove())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.Current, "the challenger loses their turn")
+	assert.Equal(t, core.Score(10), s.Players[0].Score)
+	assert.True(t, s.Board.HasTile(7, 7))
+}
+
+func TestFailedSingleChallenge(t *testing.T) {
+	s := newChallengeGame(SingleChallenge)
+	s.Play(play("cat", 7, 7, core.Horizontal))
+
+	event, err := s.Play(s.ChallengeLastMove())
+	assert.NoError(t, err)
+	assert.Equal(t, ChallengePenalty, event.Awarded)
+	assert.Equal(t, 1, s.Current)
+	assert.Equal(t, core.Score(10)+ChallengePenalty, s.Players[0].Score)
+}
+
+func TestChallengeAfterGoingOut(t *testing.T) {
+	s := newEndgame("zat", "dog")
+	s.WordList = wordSet{"cat": true, "dog": true}
+	s.Rule = DoubleChallenge
+
+	_, err := s.Play(play("zat", 7, 7, core.Horizontal))
+	assert.NoError(t, err)
+	assert.True(t, s.IsOver())
+	assert.True(t, s.CanChallenge())
+
+	_, err = s.Play(s.ChallengeLastMove())
+	assert.NoError(t, err)
+	assert.False(t, s.IsOver())
+	assert.Empty(t, s.Adjustments)
+	assert.Equal(t, core.Score(0), s.Players[1].Score)
+}
+
+func TestNothingToChallenge(t *testing.T) {
+	s := newChallengeGame(DoubleChallenge)
+	_, err := s.Play(core.ChallengeWord{})
+	assert.Equal(t, ErrNothingToChallenge, err)
+}
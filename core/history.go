@@ -0,0 +1,53 @@
+package core
+
+// placement remembers which squares a move filled so it can be taken back
+type placement struct {
+	move  PlacedTiles
+	start int
+}
+
+// Moves returns every move placed on the board which has not been undone, in order
+func (b *Board) Moves() []PlacedTiles {
+	output := make([]PlacedTiles, len(b.history))
+	for i, p := range b.history {
+		output[i] = p.move
+	}
+	return output
+}
+
+// Replay places each move in order, as if by PlaceTiles
+func (b *Board) Replay(moves []PlacedTiles) {
+	for _, move := range moves {
+		b.PlaceTiles(move)
+	}
+}
+
+// UnplaceTiles takes back the last move placed, leaving the squares it filled
+// empty again. It returns false if there is nothing to undo.
+func (b *Board) UnplaceTiles() (PlacedTiles, bool) {
+	if len(b.history) == 0 {
+		return PlacedTiles{}, false
+	}
+	last := b.history[len(b.history)-1]
+	b.history = b.history[:len(b.history)-1]
+
+	for _, square := range b.placed[last.start:] {
+		b.setTile(square, -1)
+	}
+	b.updateCrossChecks(b.placed[last.start:])
+	b.placed = b.placed[:last.start]
+	b.redo = append(b.redo, last.move)
+	return last.move, true
+}
+
+// Redo places the move most recently taken back by UnplaceTiles. Placing any
+// other move clears the moves available to redo.
+func (b *Board) Redo() (PlacedTiles, bool) {
+	if len(b.redo) == 0 {
+		return PlacedTiles{}, false
+	}
+	move := b.redo[len(b.redo)-1]
+	b.redo = b.redo[:len(b.redo)-1]
+	b.placeTiles(move)
+	return move, true
+}
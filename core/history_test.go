@@ -0,0 +1,77 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnplaceTilesRestoresCells(t *testing.T) {
+	b := NewBoard(ScrabbleLayout)
+	first := PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal}
+	b.PlaceTiles(first)
+	before := b.Clone()
+
+	// s, c and the t of cat make "scat" down through the t
+	second := PlacedTiles{Word: MakeTiles(MakeWord("sca"), "x x"), Row: 4, Col: 9, Direction: Vertical}
+	b.PlaceTiles(second)
+	assert.True(t, b.HasTile(4, 9))
+
+	move, ok := b.UnplaceTiles()
+	assert.True(t, ok)
+	assert.Equal(t, second, move)
+	assert.True(t, before.Equal(b))
+	assert.Equal(t, before.Hash(), b.Hash())
+	assert.Equal(t, []PlacedTiles{first}, b.Moves())
+
+	b.UnplaceTiles()
+	assert.True(t, b.IsEmpty())
+	assert.True(t, NewBoard(ScrabbleLayout).Equal(b))
+	assert.Zero(t, b.Hash())
+
+	_, ok = b.UnplaceTiles()
+	assert.False(t, ok)
+}
+
+func TestRedo(t *testing.T) {
+	b := NewBoard(ScrabbleLayout)
+	cat := PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal}
+	b.PlaceTiles(cat)
+	placed := b.Clone()
+
+	b.UnplaceTiles()
+	move, ok := b.Redo()
+	assert.True(t, ok)
+	assert.Equal(t, cat, move)
+	assert.True(t, placed.Equal(b))
+
+	_, ok = b.Redo()
+	assert.False(t, ok)
+
+	b.UnplaceTiles()
+	b.PlaceTiles(PlacedTiles{Word: toTiles("dog"), Row: 7, Col: 7, Direction: Horizontal})
+	_, ok = b.Redo()
+	assert.False(t, ok, "placing a new move clears redo")
+}
+
+func TestReplayMoves(t *testing.T) {
+	b := NewBoard(ScrabbleLayout)
+	b.PlaceTiles(PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal})
+	b.PlaceTiles(PlacedTiles{Word: toTiles("ar"), Row: 8, Col: 8, Direction: Vertical})
+
+	replayed := NewBoard(ScrabbleLayout)
+	replayed.Replay(b.Moves())
+	assert.True(t, b.Equal(replayed))
+	assert.Equal(t, b.Moves(), replayed.Moves())
+}
+
+func TestCloneUndoIsIndependent(t *testing.T) {
+	b := NewBoard(ScrabbleLayout)
+	b.PlaceTiles(PlacedTiles{Word: toTiles("cat"), Row: 7, Col: 7, Direction: Horizontal})
+	clone := b.Clone()
+
+	clone.UnplaceTiles()
+	assert.True(t, clone.IsEmpty())
+	assert.True(t, b.HasTile(7, 7))
+	assert.Len(t, b.Moves(), 1)
+}
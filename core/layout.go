@@ -0,0 +1,221 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxSquares is the largest number of squares a layout may have, enough for
+// a 21x21 Super Scrabble board
+const MaxSquares = 21 * 21
+
+// BoardLayout describes the shape of a board: its dimensions, where the bonus
+// squares are and which square the first move must cover.
+type BoardLayout struct {
+	Name               string
+	Rows, Cols         int
+	Bonuses            [][]Bonus
+	StartRow, StartCol int
+}
+
+// ScrabbleLayout is the standard 15x15 Scrabble board
+var ScrabbleLayout = newBuiltinLayout("scrabble", normalBonus[:])
+
+// WordsWithFriendsLayout is the classic 15x15 Words With Friends board
+var WordsWithFriendsLayout = newBuiltinLayout("wwf", wordsWithFriendsBonus[:])
+
+func newBuiltinLayout(name string, rows [][15]Bonus) *BoardLayout {
+	bonuses := make([][]Bonus, len(rows))
+	cols := 0
+	for i := range rows {
+		bonuses[i] = rows[i][:]
+		cols = len(bonuses[i])
+	}
+	return &BoardLayout{
+		Name:     name,
+		Rows:     len(rows),
+		Cols:     cols,
+		Bonuses:  bonuses,
+		StartRow: len(rows) / 2,
+		StartCol: cols / 2,
+	}
+}
+
+// IsStart returns true if the given square is the one the first move must cover
+func (l *BoardLayout) IsStart(row, col int) bool {
+	return row == l.StartRow && col == l.StartCol
+}
+
+// Validate checks that the layout is internally consistent
+func (l *BoardLayout) Validate() error {
+	if l.Rows <= 0 || l.Cols <= 0 {
+		return fmt.Errorf("layout %q has invalid dimensions %dx%d", l.Name, l.Rows, l.Cols)
+	}
+	if l.Rows*l.Cols > MaxSquares {
+		return fmt.Errorf("layout %q has %d squares, at most %d are supported", l.Name, l.Rows*l.Cols, MaxSquares)
+	}
+	if len(l.Bonuses) != l.Rows {
+		return fmt.Errorf("layout %q has %d rows of bonuses, expected %d", l.Name, len(l.Bonuses), l.Rows)
+	}
+	for i, row := range l.Bonuses {
+		if len(row) != l.Cols {
+			return fmt.Errorf("layout %q row %d has %d columns, expected %d", l.Name, i, len(row), l.Cols)
+		}
+	}
+	if l.StartRow < 0 || l.StartRow >= l.Rows || l.StartCol < 0 || l.StartCol >= l.Cols {
+		return fmt.Errorf("layout %q start square (%d,%d) is off the board", l.Name, l.StartRow, l.StartCol)
+	}
+	return nil
+}
+
+// LoadLayout reads a layout from a file. Files ending in .json are decoded as
+// JSON, anything else is parsed with ParseLayout.
+func LoadLayout(filename string) (*BoardLayout, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	if filepath.Ext(filename) == ".json" {
+		layout := &BoardLayout{Name: name}
+		if err := json.NewDecoder(f).Decode(layout); err != nil {
+			return nil, err
+		}
+		return layout, nil
+	}
+	return ParseLayout(name, f)
+}
+
+// ParseLayout reads a layout in the text format: one line per row, with each
+// square written as xx, DW, TW, DL or TL. A trailing * marks the start square,
+// which defaults to the center of the board. Blank lines and lines starting
+// with # are ignored.
+func ParseLayout(name string, r io.Reader) (*BoardLayout, error) {
+	var rows []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return parseLayoutRows(name, rows)
+}
+
+func parseLayoutRows(name string, rows []string) (*BoardLayout, error) {
+	layout := &BoardLayout{
+		Name:     name,
+		Rows:     len(rows),
+		Bonuses:  make([][]Bonus, len(rows)),
+		StartRow: -1,
+		StartCol: -1,
+	}
+	for i, line := range rows {
+		squares := strings.Fields(line)
+		if i == 0 {
+			layout.Cols = len(squares)
+		}
+		layout.Bonuses[i] = make([]Bonus, len(squares))
+		for j, square := range squares {
+			if strings.HasSuffix(square, "*") {
+				if layout.StartRow != -1 {
+					return nil, fmt.Errorf("layout %q has more than one start square", name)
+				}
+				layout.StartRow, layout.StartCol = i, j
+				square = strings.TrimSuffix(square, "*")
+			}
+			bonus, err := parseBonus(square)
+			if err != nil {
+				return nil, fmt.Errorf("layout %q row %d: %s", name, i, err)
+			}
+			layout.Bonuses[i][j] = bonus
+		}
+	}
+	if layout.StartRow == -1 {
+		layout.StartRow, layout.StartCol = layout.Rows/2, layout.Cols/2
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+func parseBonus(s string) (Bonus, error) {
+	switch strings.ToUpper(s) {
+	case "XX", "--", ".":
+		return None, nil
+	case "DW":
+		return DW, nil
+	case "TW":
+		return TW, nil
+	case "DL":
+		return DL, nil
+	case "TL":
+		return TL, nil
+	}
+	return None, fmt.Errorf("unknown bonus square %q", s)
+}
+
+type layoutJSON struct {
+	Name  string   `json:"name,omitempty"`
+	Rows  []string `json:"rows"`
+	Start *[2]int  `json:"start,omitempty"`
+}
+
+var _ json.Marshaler = &BoardLayout{}
+var _ json.Unmarshaler = &BoardLayout{}
+
+// MarshalJSON encodes the layout with each row written in the text format
+func (l *BoardLayout) MarshalJSON() ([]byte, error) {
+	out := layoutJSON{
+		Name:  l.Name,
+		Rows:  make([]string, len(l.Bonuses)),
+		Start: &[2]int{l.StartRow, l.StartCol},
+	}
+	for i, row := range l.Bonuses {
+		squares := make([]string, len(row))
+		for j, bonus := range row {
+			squares[j] = bonus.ToString()
+			if squares[j] == "" {
+				squares[j] = "xx"
+			}
+		}
+		out.Rows[i] = strings.Join(squares, " ")
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a layout written by MarshalJSON. An explicit "start"
+// overrides any start square marked in the rows.
+func (l *BoardLayout) UnmarshalJSON(buf []byte) error {
+	var in layoutJSON
+	if err := json.Unmarshal(buf, &in); err != nil {
+		return err
+	}
+	if in.Name == "" {
+		in.Name = l.Name
+	}
+	parsed, err := parseLayoutRows(in.Name, in.Rows)
+	if err != nil {
+		return err
+	}
+	if in.Start != nil {
+		parsed.StartRow, parsed.StartCol = in.Start[0], in.Start[1]
+		if err := parsed.Validate(); err != nil {
+			return err
+		}
+	}
+	*l = *parsed
+	return nil
+}
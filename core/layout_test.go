@@ -0,0 +1,76 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const practiceLayout = `
+# a tiny practice board
+TW xx xx xx TW
+xx DL xx DL xx
+xx xx xx DW* xx
+xx DL xx DL xx
+TW xx xx xx TW
+`
+
+func TestParseLayout(t *testing.T) {
+	layout, err := ParseLayout("practice", strings.NewReader(practiceLayout))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 5, layout.Rows)
+	assert.Equal(t, 5, layout.Cols)
+	assert.True(t, layout.IsStart(2, 3))
+	assert.Equal(t, DoubleWord, layout.Bonuses[2][3])
+	assert.Equal(t, TripleWord, layout.Bonuses[4][4])
+}
+
+func TestParseLayoutErrors(t *testing.T) {
+	_, err := ParseLayout("ragged", strings.NewReader("xx xx\nxx"))
+	assert.Error(t, err)
+
+	_, err = ParseLayout("unknown", strings.NewReader("xx QQ\nxx xx"))
+	assert.Error(t, err)
+
+	_, err = ParseLayout("two starts", strings.NewReader("xx* xx\nxx xx*"))
+	assert.Error(t, err)
+}
+
+func TestLayoutJSONRoundTrip(t *testing.T) {
+	buf, err := json.Marshal(WordsWithFriendsLayout)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var layout BoardLayout
+	assert.NoError(t, json.Unmarshal(buf, &layout))
+	assert.Equal(t, *WordsWithFriendsLayout, layout)
+}
+
+func TestCustomLayoutBoard(t *testing.T) {
+	layout, err := ParseLayout("practice", strings.NewReader(practiceLayout))
+	if !assert.NoError(t, err) {
+		return
+	}
+	b := NewBoard(layout)
+	assert.True(t, b.OutOfBounds(0, 5))
+	assert.False(t, b.OutOfBounds(4, 4))
+
+	assert.False(t, b.ValidateMove(PlacedTiles{toTiles("to"), 2, 0, Horizontal}, fakeWordList{}))
+	assert.True(t, b.ValidateMove(PlacedTiles{toTiles("to"), 2, 2, Horizontal}, fakeWordList{}))
+	// the o covers the DW start square, doubling the word
+	assertScore(t, b, 4, toTiles("to"), 2, 2, Horizontal)
+}
+
+func TestWordsWithFriendsLayout(t *testing.T) {
+	assert.Equal(t, 15, WordsWithFriendsLayout.Cols)
+	assert.True(t, WordsWithFriendsLayout.IsStart(7, 7))
+	b := NewBoard(WordsWithFriendsLayout)
+	assert.Equal(t, None, b.BonusAt(7, 7))
+	assert.Equal(t, TripleWord, b.BonusAt(0, 3))
+	assert.NoError(t, WordsWithFriendsLayout.Validate())
+	assert.NoError(t, ScrabbleLayout.Validate())
+}
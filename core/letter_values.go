@@ -1,6 +1,5 @@
 package core
 
-
 var letters = struct {
 	A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q, R, S, T, U, V, W, X, Y, Z int
 }{
@@ -46,3 +45,43 @@ func init() {
 	letterValues[letters.Y] = 4
 	letterValues[letters.Z] = 10
 }
+
+// Words With Friends tile values
+// (1 point)-A, E, I, O, R, S, T
+// (2 points)-D, L, N, U
+// (3 points)-G, H, Y
+// (4 points)-B, C, F, M, P, W
+// (5 points)-K, V
+// (8 points)-X
+// (10 points)-J, Q, Z
+
+var wordsWithFriendsValues [26]Score
+
+func init() {
+	wordsWithFriendsValues[letters.A] = 1
+	wordsWithFriendsValues[letters.B] = 4
+	wordsWithFriendsValues[letters.C] = 4
+	wordsWithFriendsValues[letters.D] = 2
+	wordsWithFriendsValues[letters.E] = 1
+	wordsWithFriendsValues[letters.F] = 4
+	wordsWithFriendsValues[letters.G] = 3
+	wordsWithFriendsValues[letters.H] = 3
+	wordsWithFriendsValues[letters.I] = 1
+	wordsWithFriendsValues[letters.J] = 10
+	wordsWithFriendsValues[letters.K] = 5
+	wordsWithFriendsValues[letters.L] = 2
+	wordsWithFriendsValues[letters.M] = 4
+	wordsWithFriendsValues[letters.N] = 2
+	wordsWithFriendsValues[letters.O] = 1
+	wordsWithFriendsValues[letters.P] = 4
+	wordsWithFriendsValues[letters.Q] = 10
+	wordsWithFriendsValues[letters.R] = 1
+	wordsWithFriendsValues[letters.S] = 1
+	wordsWithFriendsValues[letters.T] = 1
+	wordsWithFriendsValues[letters.U] = 2
+	wordsWithFriendsValues[letters.V] = 5
+	wordsWithFriendsValues[letters.W] = 4
+	wordsWithFriendsValues[letters.X] = 8
+	wordsWithFriendsValues[letters.Y] = 3
+	wordsWithFriendsValues[letters.Z] = 10
+}
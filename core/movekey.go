@@ -0,0 +1,58 @@
+package core
+
+// MoveKey identifies a move by the tiles it places and where. Moves with
+// equal keys are the same move. Keys are comparable, so they can be used as
+// map keys; get them from Board.MoveKey so equivalent placements agree.
+type MoveKey struct {
+	Row, Col  int
+	Direction Direction
+	// Letters holds the letter of each placed tile, one byte each
+	Letters string
+	// Blanks has bit i set if the ith placed tile is a blank
+	Blanks uint64
+}
+
+// CanonicalMove returns the standard form of a move: it starts on the first
+// empty square it covers and its tiles carry no flags. A single tile is
+// placed horizontally if it joins a horizontal word and vertically otherwise.
+func (b *Board) CanonicalMove(move PlacedTiles) PlacedTiles {
+	move = b.canonicalPlacement(move)
+	word := make([]Tile, len(move.Word))
+	for i, t := range move.Word {
+		word[i] = t.ToLetter().ToTile(t.IsBlank())
+	}
+	move.Word = word
+	return move
+}
+
+// MoveKey returns the key of a move's canonical form
+func (b *Board) MoveKey(move PlacedTiles) MoveKey {
+	move = b.canonicalPlacement(move)
+	letters := make([]byte, len(move.Word))
+	var blanks uint64
+	for i, t := range move.Word {
+		letters[i] = byte(t.ToLetter())
+		if t.IsBlank() {
+			blanks |= 1 << uint(i)
+		}
+	}
+	return MoveKey{
+		Row:       move.Row,
+		Col:       move.Col,
+		Direction: move.Direction,
+		Letters:   string(letters),
+		Blanks:    blanks,
+	}
+}
+
+// canonicalPlacement normalizes a move's start square and direction
+func (b *Board) canonicalPlacement(move PlacedTiles) PlacedTiles {
+	move = b.NormalizeMove(move)
+	if len(move.Word) == 1 {
+		move.Direction = Vertical
+		if b.HasTile(move.Row, move.Col-1) || b.HasTile(move.Row, move.Col+1) {
+			move.Direction = Horizontal
+		}
+	}
+	return move
+}
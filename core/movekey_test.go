@@ -0,0 +1,32 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveKey(t *testing.T) {
+	b := NewBoard(ScrabbleLayout)
+	b.PlaceTiles(PlacedTiles{Word: MakeTiles(MakeWord("cab"), "xxx"), Row: 7, Col: 7, Direction: Horizontal})
+
+	// starting on the board's tiles is the same as starting after them
+	cabs := PlacedTiles{Word: MakeTiles(MakeWord("s"), "x"), Row: 7, Col: 7, Direction: Horizontal}
+	assert.Equal(t, b.MoveKey(cabs), b.MoveKey(PlacedTiles{Word: cabs.Word, Row: 7, Col: 10, Direction: Vertical}))
+	assert.Equal(t, PlacedTiles{Word: cabs.Word, Row: 7, Col: 10, Direction: Horizontal}, b.CanonicalMove(cabs))
+
+	// flags don't matter but blanks do
+	flagged := PlacedTiles{Word: []Tile{Rune2Letter('s').ToTile(false).SetFlag(1, true)}, Row: 7, Col: 10}
+	assert.Equal(t, b.MoveKey(cabs), b.MoveKey(flagged))
+	assert.Equal(t, cabs.Word, b.CanonicalMove(flagged).Word)
+	blank := PlacedTiles{Word: MakeTiles(MakeWord("s"), " "), Row: 7, Col: 10}
+	assert.NotEqual(t, b.MoveKey(cabs), b.MoveKey(blank))
+
+	// a single tile only joining a vertical word is vertical
+	ta := PlacedTiles{Word: MakeTiles(MakeWord("t"), "x"), Row: 6, Col: 8, Direction: Horizontal}
+	assert.Equal(t, Vertical, b.CanonicalMove(ta).Direction)
+
+	seen := map[MoveKey]bool{b.MoveKey(cabs): true}
+	assert.True(t, seen[b.MoveKey(flagged)])
+	assert.False(t, seen[b.MoveKey(ta)])
+}
@@ -52,11 +52,11 @@ func assertScore(t *testing.T, b *Board, score Score, word []Tile, row, col int,
 func TestPointValueWithFlags(t *testing.T) {
 	tile := Rune2Letter('a').ToTile(false)
 	tile = tile.SetFlag(0, true)
-	assert.Equal(t, Score(1), tile.PointValue())
+	assert.Equal(t, Score(1), tile.PointValue(EnglishScrabble))
 }
 
 func TestRenderWithTags(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	tile := Rune2Letter('z').ToTile(true)
 	tile = tile.SetFlag(0, true)
 	tile = tile.SetFlag(3, true)
@@ -67,26 +67,26 @@ func TestRenderWithTags(t *testing.T) {
 		Col: 7, Row: 7, Word: []Tile{tile}, Direction: Horizontal,
 	})
 
-	assert.True(t, b.Cells[7][7].Tile.Flag(0))
-	assert.True(t, b.Cells[7][7].Tile.Flag(3))
+	assert.True(t, b.TileAt(7, 7).Flag(0))
+	assert.True(t, b.TileAt(7, 7).Flag(3))
 }
 
 func TestFirstWord(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	assertScore(t, b, 10, toTiles("dog"), 7, 7, Horizontal)
 
-	b = NewBoard()
+	b = NewBoard(ScrabbleLayout)
 	assertScore(t, b, 14, toTiles("goats"), 7, 7, Horizontal)
 }
 
 func TestSecondWord(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	b.PlaceTiles(PlacedTiles{toTiles("dog"), 7, 7, Horizontal})
 	assertScore(t, b, 8, toTiles("oats"), 7, 9, Vertical)
 }
 
 func TestMultiWord(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	b.PlaceTiles(PlacedTiles{toTiles("barn"), 7, 7, Horizontal})
 	words := b.FindNewWords(PlacedTiles{toTiles("bob"), 6, 7, Horizontal})
 	assert.Len(t, words, 4)
@@ -98,18 +98,18 @@ func TestMultiWord(t *testing.T) {
 }
 
 func TestPlacingJustAnS(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	b.PlaceTiles(PlacedTiles{toTiles("dog"), 7, 7, Horizontal})
 	assertScore(t, b, 6, toTiles("s"), 7, 10, Horizontal)
 }
 
 func TestUsing7Letters(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	assertScore(t, b, 134, toTiles("alfresco"), 7, 7, Horizontal)
 }
 
 func TestBlankTiles(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	assertScore(t, b, 8, MakeTiles(MakeWord("dog"), "x x"), 7, 7, Horizontal)
 }
 
@@ -136,34 +136,34 @@ func TestTileFlags(t *testing.T) {
 }
 
 func TestValidation(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	result := b.ValidateMove(PlacedTiles{toTiles("dugz"), 7, 7, Horizontal}, fakeWordList{})
 	assert.Equal(t, false, result)
 }
 
 func TestMultiValidationSuccess(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	b.PlaceTiles(PlacedTiles{toTiles("handy"), 7, 7, Horizontal})
 	result := b.ValidateMove(PlacedTiles{toTiles("stone"), 6, 5, Horizontal}, fakeWordList{})
 	assert.Equal(t, true, result)
 }
 
 func TestMultiValidation(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	b.PlaceTiles(PlacedTiles{toTiles("handy"), 7, 7, Horizontal})
 	result := b.ValidateMove(PlacedTiles{toTiles("stones"), 6, 5, Horizontal}, fakeWordList{})
 	assert.Equal(t, false, result)
 }
 
 func TestValidationDanglingWords(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	b.PlaceTiles(PlacedTiles{toTiles("handy"), 7, 7, Horizontal})
 	result := b.ValidateMove(PlacedTiles{toTiles("stones"), 5, 5, Horizontal}, fakeWordList{})
 	assert.Equal(t, false, result)
 }
 
 func TestValidationOverflowingWords(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	x := b.ValidateMove(PlacedTiles{toTiles("alfresco"), 7, 7, Horizontal}, fakeWordList{})
 	assert.Equal(t, true, x)
 	b.PlaceTiles(PlacedTiles{toTiles("alfresco"), 7, 7, Horizontal})
@@ -176,14 +176,14 @@ func TestValidationOverflowingWords(t *testing.T) {
 // Regression tests
 
 func TestJint(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 	b.PlaceTiles(PlacedTiles{toTiles("tusseh"), 14, 0, Horizontal})
 	result := b.ValidateMove(PlacedTiles{toTiles("jin"), 11, 0, Vertical}, fakeWordList{})
 	assert.False(t, result)
 }
 
 func TestBridgingWords(t *testing.T) {
-	b := NewBoard()
+	b := NewBoard(ScrabbleLayout)
 
 	b.PlaceTiles(PlacedTiles{toTiles("bat"), 7, 7, Horizontal})
 	b.PlaceTiles(PlacedTiles{toTiles("oard"), 7, 7, Vertical})
@@ -211,3 +211,62 @@ func TestConsumableRack(t *testing.T) {
 	assert.Equal(t, true, c.CanConsume(1))
 	assert.Equal(t, false, next.CanConsume(1))
 }
+
+func assertInvalid(t *testing.T, err error, reason MoveErrorReason) *InvalidMoveError {
+	t.Helper()
+	moveErr, ok := err.(*InvalidMoveError)
+	if !assert.True(t, ok, "expected an *InvalidMoveError, got %v", err) {
+		return &InvalidMoveError{}
+	}
+	assert.Equal(t, reason, moveErr.Reason, moveErr.Error())
+	return moveErr
+}
+
+func TestValidationReasons(t *testing.T) {
+	b := NewBoard(ScrabbleLayout)
+	assertInvalid(t, b.ValidateMoveDetailed(PlacedTiles{nil, 7, 7, Horizontal}, fakeWordList{}), NoTilesPlaced)
+	assertInvalid(t, b.ValidateMoveDetailed(PlacedTiles{toTiles("to"), 0, 0, Horizontal}, fakeWordList{}), MissesStartSquare)
+
+	err := assertInvalid(t, b.ValidateMoveDetailed(PlacedTiles{toTiles("stone"), 7, 12, Horizontal}, fakeWordList{}), MoveOutOfBounds)
+	assert.Equal(t, 15, err.Col)
+
+	gap := []Tile{Rune2Letter('t').ToTile(false), -1, Rune2Letter('o').ToTile(false)}
+	err = assertInvalid(t, b.ValidateMoveDetailed(PlacedTiles{gap, 7, 7, Horizontal}, fakeWordList{}), MoveHasGap)
+	assert.Equal(t, 8, err.Col)
+
+	b.PlaceTiles(PlacedTiles{toTiles("handy"), 7, 7, Horizontal})
+	assertInvalid(t, b.ValidateMoveDetailed(PlacedTiles{toTiles("to"), 0, 0, Horizontal}, fakeWordList{}), NotConnected)
+
+	err = assertInvalid(t, b.ValidateMoveDetailed(PlacedTiles{toTiles("stones"), 6, 5, Horizontal}, fakeWordList{}), InvalidWords)
+	if assert.Len(t, err.Words, 1) {
+		assert.Equal(t, "sd", Tiles2String(err.Words[0].Word))
+		assert.Equal(t, 6, err.Words[0].Row)
+		assert.Equal(t, 10, err.Words[0].Col)
+		assert.Equal(t, Vertical, err.Words[0].Direction)
+	}
+
+	assert.NoError(t, b.ValidateMoveDetailed(PlacedTiles{toTiles("stone"), 6, 5, Horizontal}, fakeWordList{}))
+}
+
+func TestValidatePlay(t *testing.T) {
+	rack := NewConsumableRack(MakeTiles(MakeWord("tonea"), "xxxx "))
+	assert.NoError(t, rack.ValidatePlay(PlacedTiles{Word: MakeTiles(MakeWord("stone"), " xxxx")}))
+
+	err := assertInvalid(t, rack.ValidatePlay(PlacedTiles{Word: toTiles("stones")}), TilesNotOnRack)
+	assert.Equal(t, "ss", Tiles2String(err.Tiles))
+}
+
+func TestValidateExchange(t *testing.T) {
+	rack := NewConsumableRack(toTiles("qatzz"))
+	bag := NewConsumableBag(EnglishScrabble)
+	assert.NoError(t, rack.ValidateExchange(Exchange{Tiles: toTiles("qz")}, bag))
+
+	assertInvalid(t, rack.ValidateExchange(Exchange{}, bag), NoTilesPlaced)
+	err := assertInvalid(t, rack.ValidateExchange(Exchange{Tiles: toTiles("qq")}, bag), TilesNotOnRack)
+	assert.Equal(t, "q", Tiles2String(err.Tiles))
+	assert.Equal(t, "exchange qq: tiles not on rack q", err.Error())
+
+	bag = bag.ConsumeTiles(bag.Remaining()[:94])
+	assert.Equal(t, 6, bag.Count())
+	assertInvalid(t, rack.ValidateExchange(Exchange{Tiles: toTiles("qz")}, bag), ExchangeNotAllowed)
+}
@@ -8,6 +8,8 @@ import (
 // Tile represents an actual physical tile on the board
 type Tile int
 
+// ToRune returns the English letter of the tile. Letters of other alphabets
+// are written by Alphabet.TileString.
 func (t Tile) ToRune() rune {
 	return t.ToLetter().ToRune()
 }
@@ -23,12 +25,9 @@ func (t Tile) GoString() string {
 	return fmt.Sprintf("core.Rune2Letter(%q).ToTile(%#v)", t.ToRune(), t.IsBlank())
 }
 
-// PointValue returns the Score associated with a Tile
-func (t Tile) PointValue() Score {
-	if t.IsBlank() {
-		return 0
-	}
-	return letterValues[t.ToLetter()]
+// PointValue returns the Score associated with a Tile in the given TileSet
+func (t Tile) PointValue(set *TileSet) Score {
+	return set.PointValue(t)
 }
 
 // IsBlank returns true for blank tiles
@@ -82,6 +81,8 @@ func Rune2Letter(r rune) Letter {
 	return Letter(r - 'a')
 }
 
+// Tiles2String writes English tiles with blanks in uppercase, see
+// Alphabet.TilesString for other alphabets
 func Tiles2String(tiles []Tile) string {
 	word := ""
 	for _, l := range tiles {
@@ -90,7 +91,17 @@ func Tiles2String(tiles []Tile) string {
 	return word
 }
 
-func tiles2Word(tiles []Tile) Word {
+// String2Tiles parses tiles written by Tiles2String, where capitals are blanks
+func String2Tiles(word string) []Tile {
+	tiles := make([]Tile, 0, len(word))
+	for _, r := range word {
+		tiles = append(tiles, Rune2Letter(unicode.ToLower(r)).ToTile(unicode.IsUpper(r)))
+	}
+	return tiles
+}
+
+// Tiles2Word returns the letters of the tiles, forgetting which are blank
+func Tiles2Word(tiles []Tile) Word {
 	word := make(Word, len(tiles))
 	for i, l := range tiles {
 		word[i] = l.ToLetter()
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxBagTiles is the largest bag a Bag's consumed bitfield can track
+const maxBagTiles = 254
+
+// TileSet describes the tiles a game is played with: how many of each letter
+// are in the bag, what they are worth and how many are drawn onto a rack.
+type TileSet struct {
+	Name       string
+	Alphabet   *Alphabet
+	Counts     []int
+	Blanks     int
+	Values     []Score
+	RackSize   int
+	BingoBonus Score
+
+	tiles []Tile
+}
+
+// A-9, B-2, C-2, D-4, E-12, F-2, G-3, H-2, I-9, J-1, K-1, L-4, M-2, N-6, O-8, P-2, Q-1, R-6, S-4, T-6, U-4, V-2, W-2, X-1, Y-2, Z-1 and Blanks-2.
+const allLetters = "aaaaaaaaabbccddddeeeeeeeeeeeeffggghhiiiiiiiiijkllllmmnnnnnnooooooooppqrrrrrrssssttttttuuuuvvwwxyyz"
+
+// A-9, B-2, C-2, D-5, E-13, F-2, G-3, H-4, I-8, J-1, K-1, L-4, M-2, N-5, O-8, P-2, Q-1, R-6, S-5, T-7, U-4, V-2, W-2, X-1, Y-2, Z-1 and Blanks-2.
+const wordsWithFriendsLetters = "aaaaaaaaabbccdddddeeeeeeeeeeeeeffggghhhhiiiiiiiijkllllmmnnnnnooooooooppqrrrrrrssssstttttttuuuuvvwwxyyz"
+
+// EnglishScrabble is the standard English Scrabble tile distribution
+var EnglishScrabble = NewTileSet("scrabble", English, countLetters(allLetters), 2, letterValues[:], 7, 50)
+
+// WordsWithFriends is the Words With Friends tile distribution
+var WordsWithFriends = NewTileSet("wwf", English, countLetters(wordsWithFriendsLetters), 2, wordsWithFriendsValues[:], 7, 35)
+
+// FrenchScrabble is the French Scrabble tile distribution
+var FrenchScrabble = mustParseTileSet("scrabble-fr", French, 2, 7, 50, `
+	a 9 1  b 2 3  c 2 3  d 3 2  e 15 1  f 2 4  g 2 2  h 2 4  i 8 1  j 1 8
+	k 1 10  l 5 1  m 3 2  n 6 1  o 6 1  p 2 3  q 1 8  r 6 1  s 6 1  t 6 1
+	u 6 1  v 2 4  w 1 10  x 1 10  y 1 10  z 1 10`)
+
+// SpanishScrabble is the Spanish Scrabble tile distribution
+var SpanishScrabble = mustParseTileSet("scrabble-es", Spanish, 2, 7, 50, `
+	a 12 1  b 2 3  c 4 3  ch 1 5  d 5 2  e 12 1  f 1 4  g 2 2  h 2 4  i 6 1
+	j 1 8  l 4 1  ll 1 8  m 2 3  n 5 1  ñ 1 8  o 9 1  p 2 3  q 1 5  r 5 1
+	rr 1 8  s 6 1  t 4 1  u 5 1  v 1 4  x 1 8  y 1 4  z 1 10`)
+
+// GermanScrabble is the German Scrabble tile distribution
+var GermanScrabble = mustParseTileSet("scrabble-de", German, 2, 7, 50, `
+	a 5 1  b 2 3  c 2 4  d 4 1  e 15 1  f 2 4  g 3 2  h 4 2  i 6 1  j 1 6
+	k 2 4  l 3 2  m 4 3  n 9 1  o 3 2  p 1 4  q 1 10  r 6 1  s 7 1  t 6 1
+	u 6 1  v 1 6  w 1 3  x 1 8  y 1 10  z 1 3  ä 1 6  ö 1 8  ü 1 6`)
+
+var builtinTileSets = []*TileSet{EnglishScrabble, WordsWithFriends, FrenchScrabble, SpanishScrabble, GermanScrabble}
+
+// LookupTileSet returns the built in tile set with the given name
+func LookupTileSet(name string) (*TileSet, bool) {
+	for _, t := range builtinTileSets {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// TileSetFor returns the first built in tile set spelled with the alphabet
+func TileSetFor(alphabet *Alphabet) (*TileSet, bool) {
+	for _, t := range builtinTileSets {
+		if t.Alphabet == alphabet {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// NewTileSet creates a TileSet. counts and values are indexed by Letter.
+func NewTileSet(name string, alphabet *Alphabet, counts []int, blanks int, values []Score, rackSize int, bingoBonus Score) *TileSet {
+	t := &TileSet{
+		Name:       name,
+		Alphabet:   alphabet,
+		Counts:     counts,
+		Blanks:     blanks,
+		Values:     values,
+		RackSize:   rackSize,
+		BingoBonus: bingoBonus,
+	}
+	for l, n := range counts {
+		for i := 0; i < n; i++ {
+			t.tiles = append(t.tiles, Letter(l).ToTile(false))
+		}
+	}
+	for i := 0; i < blanks; i++ {
+		t.tiles = append(t.tiles, Letter(0).ToTile(true))
+	}
+	if len(t.tiles) > maxBagTiles {
+		panic(fmt.Sprintf("tile set %q has %d tiles, at most %d are supported", name, len(t.tiles), maxBagTiles))
+	}
+	return t
+}
+
+// Size returns the total number of tiles in the set
+func (t *TileSet) Size() int {
+	return len(t.tiles)
+}
+
+// PointValue returns the Score of a single tile. Blanks are worth nothing.
+func (t *TileSet) PointValue(tile Tile) Score {
+	if tile.IsBlank() {
+		return 0
+	}
+	return t.Values[tile.ToLetter()]
+}
+
+// Sum returns the total value of the given tiles
+func (t *TileSet) Sum(tiles []Tile) Score {
+	total := Score(0)
+	for _, tile := range tiles {
+		total += t.PointValue(tile)
+	}
+	return total
+}
+
+// ParseTileSet reads a distribution written as whitespace separated
+// "letter count value" triples. Letters not mentioned are not in the bag.
+func ParseTileSet(name string, alphabet *Alphabet, blanks, rackSize int, bingoBonus Score, spec string) (*TileSet, error) {
+	counts := make([]int, alphabet.Size())
+	values := make([]Score, alphabet.Size())
+	fields := strings.Fields(spec)
+	if len(fields)%3 != 0 {
+		return nil, fmt.Errorf("tile set %q: expected letter, count and value triples", name)
+	}
+	for i := 0; i < len(fields); i += 3 {
+		word, err := alphabet.Parse(fields[i])
+		if err != nil || len(word) != 1 {
+			return nil, fmt.Errorf("tile set %q: %q is not a single letter", name, fields[i])
+		}
+		count, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("tile set %q: bad count for %q: %s", name, fields[i], err)
+		}
+		value, err := strconv.Atoi(fields[i+2])
+		if err != nil {
+			return nil, fmt.Errorf("tile set %q: bad value for %q: %s", name, fields[i], err)
+		}
+		counts[word[0]] = count
+		values[word[0]] = Score(value)
+	}
+	return NewTileSet(name, alphabet, counts, blanks, values, rackSize, bingoBonus), nil
+}
+
+func mustParseTileSet(name string, alphabet *Alphabet, blanks, rackSize int, bingoBonus Score, spec string) *TileSet {
+	t, err := ParseTileSet(name, alphabet, blanks, rackSize, bingoBonus, spec)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func countLetters(letters string) []int {
+	counts := make([]int, 26)
+	for _, l := range MakeWord(letters) {
+		counts[l]++
+	}
+	return counts
+}
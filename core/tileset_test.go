@@ -0,0 +1,30 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileSetSizes(t *testing.T) {
+	assert.Equal(t, 100, EnglishScrabble.Size())
+	assert.Equal(t, 104, WordsWithFriends.Size())
+	assert.Equal(t, 104, NewConsumableBag(WordsWithFriends).Count())
+}
+
+func TestTileSetPointValues(t *testing.T) {
+	z := Rune2Letter('z').ToTile(false)
+	b := Rune2Letter('b').ToTile(false)
+	assert.Equal(t, Score(10), z.PointValue(EnglishScrabble))
+	assert.Equal(t, Score(3), b.PointValue(EnglishScrabble))
+	assert.Equal(t, Score(4), b.PointValue(WordsWithFriends))
+	assert.Equal(t, Score(0), Rune2Letter('z').ToTile(true).PointValue(WordsWithFriends))
+	assert.Equal(t, Score(14), EnglishScrabble.Sum(toTiles("zap")))
+}
+
+func TestWordsWithFriendsBingo(t *testing.T) {
+	b := NewBoard(WordsWithFriendsLayout)
+	b.TileSet = WordsWithFriends
+	// a-1 l-2 f-4 r-1 e-1 s-1 c-4 o-1, with the e on a double word, plus 35 for a bingo
+	assertScore(t, b, 15*2+35, toTiles("alfresco"), 7, 7, Horizontal)
+}
@@ -49,12 +49,28 @@ func (p Pass) MarshalJSON() ([]byte, error) {
 	}{Type: "pass"})
 }
 
-type Exchange struct{}
+// Exchange returns Tiles to the bag and draws the same number of new tiles
+type Exchange struct {
+	Tiles []Tile
+}
 
 var _ Turn = Exchange{}
 
 func (Exchange) isTurn() {}
 
+var _ json.Marshaler = Exchange{}
+
+func (e Exchange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Tiles []Tile `json:"tiles"`
+		Type  string `json:"type"`
+	}{Tiles: e.Tiles, Type: "exchange"})
+}
+
+func (e Exchange) String() string {
+	return fmt.Sprintf("(exchange %s)", Tiles2String(e.Tiles))
+}
+
 type ChallengeWord struct {
 	Move PlacedTiles
 }
@@ -0,0 +1,197 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MoveErrorReason is the reason a move was rejected
+type MoveErrorReason int
+
+// Reasons a move can be rejected
+const (
+	NoTilesPlaced MoveErrorReason = iota + 1
+	MoveOutOfBounds
+	MoveHasGap
+	MissesStartSquare
+	NotConnected
+	InvalidWords
+	TilesNotOnRack
+	ExchangeNotAllowed
+)
+
+func (r MoveErrorReason) String() string {
+	switch r {
+	case NoTilesPlaced:
+		return "no tiles placed"
+	case MoveOutOfBounds:
+		return "out of bounds"
+	case MoveHasGap:
+		return "gap in placement"
+	case MissesStartSquare:
+		return "first move misses the start square"
+	case NotConnected:
+		return "not connected to other words"
+	case InvalidWords:
+		return "invalid words"
+	case TilesNotOnRack:
+		return "tiles not on rack"
+	case ExchangeNotAllowed:
+		return "too few tiles in the bag to exchange"
+	}
+	return "unknown reason"
+}
+
+// InvalidMoveError describes why a move is illegal. Row and Col locate the
+// offending square for MoveOutOfBounds and MoveHasGap, Words lists the
+// offending words for InvalidWords and Tiles the missing tiles for
+// TilesNotOnRack. Exchange is set when the rejected turn was an exchange of
+// the tiles in Move.Word.
+type InvalidMoveError struct {
+	Reason   MoveErrorReason
+	Move     PlacedTiles
+	Row, Col int
+	Words    []PlacedTiles
+	Tiles    []Tile
+	Exchange bool
+}
+
+func (e *InvalidMoveError) Error() string {
+	if e.Exchange {
+		if e.Reason == TilesNotOnRack {
+			return fmt.Sprintf("exchange %s: %s %s", Tiles2String(e.Move.Word), e.Reason, Tiles2String(e.Tiles))
+		}
+		return fmt.Sprintf("exchange %s: %s", Tiles2String(e.Move.Word), e.Reason)
+	}
+	switch e.Reason {
+	case MoveOutOfBounds, MoveHasGap:
+		return fmt.Sprintf("%s: %s at (%d,%d)", e.Move, e.Reason, e.Row, e.Col)
+	case InvalidWords:
+		words := make([]string, len(e.Words))
+		for i, w := range e.Words {
+			words[i] = w.String()
+		}
+		return fmt.Sprintf("%s: %s %s", e.Move, e.Reason, strings.Join(words, ", "))
+	case TilesNotOnRack:
+		return fmt.Sprintf("%s: %s %s", e.Move, e.Reason, Tiles2String(e.Tiles))
+	}
+	return fmt.Sprintf("%s: %s", e.Move, e.Reason)
+}
+
+// ValidateMoveDetailed returns nil if the given move is legal, otherwise an
+// *InvalidMoveError describing the first problem found.
+func (b *Board) ValidateMoveDetailed(move PlacedTiles, wordList WordList) error {
+	if b.StoreValidatedMoves {
+		b.ValidatedMoves = append(b.ValidatedMoves, move)
+	}
+	if len(move.Word) == 0 {
+		return &InvalidMoveError{Reason: NoTilesPlaced, Move: move}
+	}
+
+	// Check that it connects to other words
+	connectsToOtherWords := false
+	coversStart := false
+	dRow, dCol := move.Direction.Offsets()
+	wordPos := 0
+	for progress := 0; wordPos < len(move.Word); progress++ {
+		tileRow := move.Row + dRow*progress
+		tileCol := move.Col + dCol*progress
+
+		if b.OutOfBounds(tileRow, tileCol) {
+			return &InvalidMoveError{Reason: MoveOutOfBounds, Move: move, Row: tileRow, Col: tileCol}
+		}
+		if !b.HasTile(tileRow, tileCol) {
+			if move.Word[wordPos].IsNoTile() {
+				return &InvalidMoveError{Reason: MoveHasGap, Move: move, Row: tileRow, Col: tileCol}
+			}
+			wordPos++
+		}
+
+		if b.Layout.IsStart(tileRow, tileCol) {
+			coversStart = true
+		}
+		if !connectsToOtherWords {
+			if coversStart {
+				connectsToOtherWords = true
+			} else if b.HasTile(tileRow-1, tileCol) ||
+				b.HasTile(tileRow+1, tileCol) ||
+				b.HasTile(tileRow, tileCol-1) ||
+				b.HasTile(tileRow, tileCol+1) {
+				connectsToOtherWords = true
+			}
+		}
+	}
+
+	if !connectsToOtherWords {
+		if b.IsEmpty() {
+			return &InvalidMoveError{Reason: MissesStartSquare, Move: move}
+		}
+		return &InvalidMoveError{Reason: NotConnected, Move: move}
+	}
+
+	var invalid []PlacedTiles
+	for _, word := range b.FindNewWords(move) {
+		if !wordList.Contains(Tiles2Word(word.Word)) {
+			invalid = append(invalid, word)
+		}
+	}
+	if len(invalid) > 0 {
+		return &InvalidMoveError{Reason: InvalidWords, Move: move, Words: invalid}
+	}
+	return nil
+}
+
+// IsEmpty returns true if no tiles have been placed on the board
+func (b *Board) IsEmpty() bool {
+	for _, square := range b.squares {
+		if square != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePlay returns nil if every tile in the move can be played from the
+// rack, otherwise an *InvalidMoveError listing the missing tiles.
+func (c Rack) ValidatePlay(move PlacedTiles) error {
+	if missing := c.missing(move.Word); len(missing) > 0 {
+		return &InvalidMoveError{Reason: TilesNotOnRack, Move: move, Tiles: missing}
+	}
+	return nil
+}
+
+// ValidateExchange returns nil if the exchange is allowed by the rules,
+// otherwise an *InvalidMoveError explaining why not.
+func (c Rack) ValidateExchange(exchange Exchange, bag Bag) error {
+	move := PlacedTiles{Word: exchange.Tiles}
+	if len(exchange.Tiles) == 0 {
+		return &InvalidMoveError{Reason: NoTilesPlaced, Move: move, Exchange: true}
+	}
+	if !bag.CanExchange() {
+		return &InvalidMoveError{Reason: ExchangeNotAllowed, Move: move, Exchange: true}
+	}
+	if missing := c.missing(exchange.Tiles); len(missing) > 0 {
+		return &InvalidMoveError{Reason: TilesNotOnRack, Move: move, Tiles: missing, Exchange: true}
+	}
+	return nil
+}
+
+// missing returns the tiles which are not on the rack
+func (c Rack) missing(tiles []Tile) []Tile {
+	remaining := make([]Tile, len(c.Rack))
+	copy(remaining, c.Rack)
+
+	var missing []Tile
+outer:
+	for _, t := range tiles {
+		for i := range remaining {
+			if tilesEqual(remaining[i], t) {
+				remaining[i] = remaining[len(remaining)-1]
+				remaining = remaining[:len(remaining)-1]
+				continue outer
+			}
+		}
+		missing = append(missing, t)
+	}
+	return missing
+}
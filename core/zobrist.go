@@ -0,0 +1,41 @@
+package core
+
+// zobristTiles is the number of distinct tiles hashed: every letter, blank or not
+const zobristTiles = 2 * MaxLetters
+
+// zobrist holds a random key for each tile on each square. Hashing ignores
+// tile flags, which don't change the position.
+var zobrist = makeZobristTable()
+
+func makeZobristTable() *[MaxSquares][zobristTiles]uint64 {
+	table := new([MaxSquares][zobristTiles]uint64)
+	// xorshift64* with a fixed seed, so hashes are stable between runs
+	state := uint64(0x9E3779B97F4A7C15)
+	for i := range table {
+		for j := range table[i] {
+			state ^= state >> 12
+			state ^= state << 25
+			state ^= state >> 27
+			table[i][j] = state * 0x2545F4914F6CDD1D
+		}
+	}
+	return table
+}
+
+func zobristKey(square int, t Tile) uint64 {
+	if t.IsNoTile() {
+		return 0
+	}
+	index := int(t.ToLetter())
+	if t.IsBlank() {
+		index += MaxLetters
+	}
+	return zobrist[square][index]
+}
+
+// Hash returns a Zobrist hash of the tiles on the board, suitable for keying
+// transposition tables. Boards with the same tiles on the same squares hash
+// the same no matter what order the moves were played in.
+func (b *Board) Hash() uint64 {
+	return b.hash
+}
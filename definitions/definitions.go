@@ -36,6 +36,35 @@ func LoadDefinitionsReader(f io.Reader, db WordDB) error {
 	return scanner.Err()
 }
 
+// Source looks up the definitions of words
+type Source interface {
+	// Define returns the definition of a lowercase word, or
+	// ErrNoDefinition if the source doesn't know it
+	Define(word string) (string, error)
+}
+
+var ErrNoDefinition = errors.New("No definition")
+
+// Sources tries each source in turn, returning the first definition found
+type Sources []Source
+
+func (s Sources) Define(word string) (string, error) {
+	for _, src := range s {
+		definition, err := src.Define(word)
+		if err != ErrNoDefinition {
+			return definition, err
+		}
+	}
+	return "", ErrNoDefinition
+}
+
+// Hasbro scrapes definitions from scrabble.hasbro.com
+type Hasbro struct{}
+
+func (Hasbro) Define(word string) (string, error) {
+	return defineWord(word)
+}
+
 func defineWord(word string) (string, error) {
 	form := url.Values{
 		"dictWord": {word},
@@ -55,68 +84,7 @@ func defineWord(word string) (string, error) {
 	matcher := regexp.MustCompile("(?is)" + word + "(.*)")
 	core := matcher.FindStringSubmatch(definition)
 	if core == nil {
-		return "", errors.New("No definition")
+		return "", ErrNoDefinition
 	}
 	return strings.Join(strings.Fields(core[1]), " "), nil
 }
-
-// func checkWords(filename string) {
-// 	words, err := LoadWords(filename)
-// 	if err != nil {
-// 		panic(err)
-// 	}
-
-// 	start := 0
-
-// 	output, err := os.OpenFile("fast-words.csv", os.O_RDWR|os.O_APPEND, 0660)
-// 	if err != nil {
-// 		panic(err)
-// 	}
-// 	defer output.Close()
-
-// 	type Result struct {
-// 		Word, Definition, Error string
-// 	}
-
-// 	jobs := make(chan string, 100)
-// 	results := make(chan Result, 100)
-// 	for i := 0; i < 100; i++ {
-// 		go func() {
-// 			for j := range jobs {
-// 				definition, err := defineWord(j)
-// 				errString := ""
-// 				if err != nil {
-// 					errString = err.Error()
-// 				}
-// 				results <- Result{
-// 					Word:       j,
-// 					Error:      errString,
-// 					Definition: definition,
-// 				}
-// 			}
-// 		}()
-// 	}
-
-// 	go func() {
-// 		for _, word := range words[start:] {
-// 			jobs <- word
-// 		}
-// 		close(jobs)
-// 	}()
-
-// 	wr := csv.NewWriter(output)
-// 	for res := range results {
-// 		err = wr.Write([]string{
-// 			res.Word,
-// 			res.Definition,
-// 			res.Error,
-// 		})
-// 		if err != nil {
-// 			panic(err)
-// 		}
-// 		fmt.Println(res.Word, err == nil)
-// 		wr.Flush()
-// 	}
-// 	wr.Flush()
-
-// }
@@ -0,0 +1,122 @@
+package definitions
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Store is a Source held in memory, keyed by lowercase word
+type Store map[string]string
+
+func (s Store) Define(word string) (string, error) {
+	definition, ok := s[word]
+	if !ok {
+		return "", ErrNoDefinition
+	}
+	return definition, nil
+}
+
+// LoadStoreFile reads a store from a file, tab separated if its name ends
+// in .tsv and comma separated otherwise
+func LoadStoreFile(filename string) (Store, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	comma := ','
+	if strings.EqualFold(filepath.Ext(filename), ".tsv") {
+		comma = '\t'
+	}
+	return LoadStore(f, comma)
+}
+
+// LoadStore reads rows of word and definition separated by comma. Any
+// further columns, like the error column written by earlier scrapes, are
+// ignored, as are rows without a definition.
+func LoadStore(r io.Reader, comma rune) (Store, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	store := Store{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return store, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(row) < 2 || row[1] == "" {
+			continue
+		}
+		store[strings.ToLower(row[0])] = row[1]
+	}
+}
+
+// WriteStore looks up every word with src using the given number of
+// concurrent workers and writes those defined as comma separated rows,
+// which LoadStore can read back. It stops at the first error other than
+// ErrNoDefinition.
+func WriteStore(w io.Writer, src Source, words []string, workers int) error {
+	type result struct {
+		word, definition string
+		err              error
+	}
+	jobs := make(chan string)
+	results := make(chan result)
+	done := make(chan struct{})
+	defer close(done)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for word := range jobs {
+				definition, err := src.Define(word)
+				select {
+				case results <- result{word, definition, err}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, word := range words {
+			select {
+			case jobs <- word:
+			case <-done:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	wr := csv.NewWriter(w)
+	for res := range results {
+		if res.err == ErrNoDefinition {
+			continue
+		}
+		if res.err != nil {
+			return res.err
+		}
+		if err := wr.Write([]string{res.word, res.definition}); err != nil {
+			return err
+		}
+	}
+	wr.Flush()
+	return wr.Error()
+}
@@ -0,0 +1,54 @@
+package definitions
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadStore(t *testing.T) {
+	store, err := LoadStore(strings.NewReader("Cab,a taxi\nqi,\"life force, in Chinese philosophy\",\nzzz,,No definition\n"), ',')
+	assert.NoError(t, err)
+	assert.Equal(t, Store{"cab": "a taxi", "qi": "life force, in Chinese philosophy"}, store)
+
+	store, err = LoadStore(strings.NewReader("cab\ta taxi, for hire\n"), '\t')
+	assert.NoError(t, err)
+	assert.Equal(t, Store{"cab": "a taxi, for hire"}, store)
+}
+
+func TestSources(t *testing.T) {
+	src := Sources{Store{"cab": "a taxi"}, Store{"cab": "a cabbage", "qi": "life force"}}
+
+	definition, err := src.Define("cab")
+	assert.NoError(t, err)
+	assert.Equal(t, "a taxi", definition)
+
+	definition, err = src.Define("qi")
+	assert.NoError(t, err)
+	assert.Equal(t, "life force", definition)
+
+	_, err = src.Define("zzz")
+	assert.Equal(t, ErrNoDefinition, err)
+}
+
+type failingSource struct{}
+
+func (failingSource) Define(word string) (string, error) {
+	return "", errors.New("offline")
+}
+
+func TestWriteStore(t *testing.T) {
+	src := Store{"cab": "a taxi", "qi": "life force, in Chinese philosophy"}
+	var buf bytes.Buffer
+	assert.NoError(t, WriteStore(&buf, src, []string{"cab", "qi", "zzz"}, 2))
+
+	store, err := LoadStore(&buf, ',')
+	assert.NoError(t, err)
+	assert.Equal(t, src, store)
+
+	err = WriteStore(&buf, Sources{src, failingSource{}}, []string{"cab", "zzz", "qi"}, 2)
+	assert.EqualError(t, err, "offline")
+}
@@ -50,6 +50,11 @@ export class App extends React.Component<Props, State> {
                 <BoardView tiles={store.board}/>
                 <div className="right-panel">
                     <MovePanel gameState={this.props.gameState} moves={store.moves}/>
+                    <div className="history-controls">
+                        <button onClick={() => this.props.gameState.undo()}>Undo</button>
+                        <button disabled={!store.undone || store.undone.length === 0}
+                                onClick={() => this.props.gameState.redo()}>Redo</button>
+                    </div>
                     <ScoreBoard moves={store.moves} scores={store.moves.map((x) => x.score)}/>
                     <div className="player-rack">
                         <RackInput
@@ -127,7 +132,7 @@ class MovePanel extends React.Component<MovePanelProps> {
         }
 
         return (
-            <div className={move.valid === false ? 'move-panel-move error-icon' : 'move-panel-move'} key={i}>
+            <div className={move.valid === false ? 'move-panel-move error-icon' : 'move-panel-move'} title={move.invalidReason} key={i}>
                 <RackInput
                     score={move.score}
                     mini
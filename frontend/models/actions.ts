@@ -1,4 +1,4 @@
-import { Tile, Move, Board } from "./core";
+import { Tile, Move, Board, Validation } from "./core";
 
 export interface SetRack {
     changesBoard: true;
@@ -25,6 +25,16 @@ export interface AddMove {
     value: Move;
 }
 
+export interface UndoMove {
+    changesBoard: true;
+    type: "undomove";
+}
+
+export interface RedoMove {
+    changesBoard: true;
+    type: "redomove";
+}
+
 export interface ReceiveRender {
     changesBoard: false;
     type: "receiverender";
@@ -35,7 +45,7 @@ export interface ReceiveRender {
 export interface ReceiveValidations {
     changesBoard: false;
     type: "receivevalidations";
-    validations: boolean[];
+    validations: Validation[];
 }
 
 export interface ReceivePlay {
@@ -55,6 +65,8 @@ export type Action =
     | UpdateMove
     | DeleteMove
     | AddMove
+    | UndoMove
+    | RedoMove
     | ReceiveRender
     | ReceiveValidations
     | ReceivePlay
@@ -76,11 +88,19 @@ export function updateMove(value: Move, index: number): UpdateMove {
     return { type: "updatemove", value, index, changesBoard: true };
 }
 
+export function undoMove(): UndoMove {
+    return { type: "undomove", changesBoard: true };
+}
+
+export function redoMove(): RedoMove {
+    return { type: "redomove", changesBoard: true };
+}
+
 export function receiveRender(board: Board, scores: number[]): ReceiveRender {
     return { type: "receiverender", board, scores, changesBoard: false };
 }
 
-export function receiveValidations(validations: boolean[]): ReceiveValidations {
+export function receiveValidations(validations: Validation[]): ReceiveValidations {
     return { type: "receivevalidations", validations, changesBoard: false };
 }
 
@@ -20,11 +20,66 @@ export interface Move {
     direction: "horizontal" | "vertical";
     score?: number;
     valid?: boolean;
+    invalidReason?: string;
+    definitions?: Definition[];
+}
+
+export interface Definition {
+    word: string;
+    definition?: string;
+}
+
+export interface Component {
+    name: string;
+    value: number;
+}
+
+export interface RankedMove {
+    tiles: Tile[];
+    row: number;
+    col: number;
+    direction: "horizontal" | "vertical";
+    score: number;
+    leave: Tile[];
+    value: number;
+    components: Component[];
+}
+
+export interface SimResult extends RankedMove {
+    iterations: number;
+    equity: number;
+    stdDev: number;
+    winRate: number;
+}
+
+export interface InvalidWord {
+    word: string;
+    row: number;
+    col: number;
+    direction: "horizontal" | "vertical";
+}
+
+export interface Validation {
+    valid: boolean;
+    reason?: string;
+    error?: string;
+    words?: InvalidWord[];
+    definitions?: Definition[];
 }
 
 export interface MoveRequest {
     moves: Move[];
     rack: Tile[];
+    lexicon?: string;
+}
+
+export interface MovesRequest extends MoveRequest {
+    count?: number;
+    evaluator?: string;
+}
+
+export interface AnalyzeRequest extends MovesRequest {
+    spread?: number;
 }
 
 export interface RenderedBoard {
@@ -1,7 +1,7 @@
 import { Move, Tile } from "./core";
 import { AppStore } from "./store";
 import { Store } from "redux";
-import { setRack, updateMove, addMove, deleteMove } from "./actions";
+import { setRack, updateMove, addMove, deleteMove, undoMove, redoMove } from "./actions";
 
 export class GameState {
     constructor(private store: Store<AppStore>) {}
@@ -18,6 +18,12 @@ export class GameState {
     removeMove(i: number) {
         this.store.dispatch(deleteMove(i));
     }
+    undo() {
+        this.store.dispatch(undoMove());
+    }
+    redo() {
+        this.store.dispatch(redoMove());
+    }
 
     subscribe(setState: (s: AppStore) => void) {
         this.store.subscribe(() => {
@@ -5,6 +5,7 @@ import { GameService, LocalStorage } from "../services/game";
 
 export interface AppStore {
     moves: Move[];
+    undone: Move[];
     rack: Tile[];
     board: Board;
     play: Move;
@@ -21,6 +22,7 @@ export const EmptyMove: Move = {
 
 export const DefaultState: AppStore = {
     moves: [ EmptyMove ],
+    undone: [],
     rack: [],
     board: Array(15).map(() => Array(15).map(() => null)),
     play: EmptyMove,
@@ -112,6 +114,24 @@ export class AppState {
         switch (action.type) {
             case "addmove":
                 state.moves = [ ...state.moves, action.value ];
+                state.undone = [];
+                return state;
+            case "undomove":
+                if (state.moves.length === 0 || state.moves[state.moves.length - 1].tiles.length === 0) {
+                    return state;
+                }
+                state.moves = [ ...state.moves ];
+                state.undone = [ ...state.undone, state.moves.pop() ];
+                if (state.moves.length === 0) {
+                    state.moves.push(EmptyMove);
+                }
+                return state;
+            case "redomove":
+                if (state.undone.length === 0) {
+                    return state;
+                }
+                state.undone = [ ...state.undone ];
+                state.moves = [ ...state.moves.filter((m) => m.tiles.length > 0), state.undone.pop() ];
                 return state;
             case "deletemove":
                 state.moves = [ ...state.moves ];
@@ -137,7 +157,8 @@ export class AppState {
             case "receivevalidations":
                 state.moves = [ ...state.moves ];
                 state.moves.forEach((move, i) => {
-                    move.valid = action.validations[i];
+                    move.valid = action.validations[i].valid;
+                    move.invalidReason = action.validations[i].error;
                 });
                 return state;
             case "receiveplay":
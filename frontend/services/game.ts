@@ -1,4 +1,4 @@
-import { Move, MoveRequest, RenderedBoard, Tile } from "../models/core";
+import { AnalyzeRequest, Move, MoveRequest, MovesRequest, RankedMove, RenderedBoard, SimResult, Tile, Validation } from "../models/core";
 import { DefaultState } from "../models/store";
 
 declare const core: {
@@ -22,7 +22,21 @@ export class GameService {
         }).then((x) => x.json());
     }
 
-    async validate(req: MoveRequest): Promise<boolean[]> {
+    async moves(req: MovesRequest): Promise<RankedMove[]> {
+        return await fetch("/moves", {
+            method: "POST",
+            body: JSON.stringify(req),
+        }).then((x) => x.json());
+    }
+
+    async analyze(req: AnalyzeRequest): Promise<SimResult[]> {
+        return await fetch("/analyze", {
+            method: "POST",
+            body: JSON.stringify(req),
+        }).then((x) => x.json());
+    }
+
+    async validate(req: MoveRequest): Promise<Validation[]> {
         return await fetch("/validate", {
             method: "POST",
             body: JSON.stringify(req),
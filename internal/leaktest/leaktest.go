@@ -0,0 +1,31 @@
+// Package leaktest checks that tests stop every goroutine they start.
+package leaktest
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// Check counts the goroutines running now. The function it returns fails t
+// if more are still running after a few seconds, so a test can
+// `defer leaktest.Check(t)()`.
+func Check(t *testing.T) func() {
+	before := runtime.NumGoroutine()
+	return func() {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			n := runtime.NumGoroutine()
+			if n <= before {
+				return
+			}
+			if time.Now().After(deadline) {
+				stacks := make([]byte, 1<<20)
+				stacks = stacks[:runtime.Stack(stacks, true)]
+				t.Errorf("%d goroutines leaked:\n%s", n-before, stacks)
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
@@ -1,29 +1,85 @@
 package main
 
 import (
+	"context"
+	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/definitions"
 	"github.com/Logiraptor/word-bot/web"
 	"github.com/Logiraptor/word-bot/wordlist"
 )
 
-var wordDB *wordlist.Trie
-
-func init() {
-	wordDB = wordlist.MakeDefaultWordList()
-}
-
 func main() {
+	lexicons, err := wordlist.LoadRegistry()
+	if err != nil {
+		log.Fatal(err)
+	}
+	wordDB, err := wordlist.Default.Trie()
+	if err != nil {
+		log.Fatal(err)
+	}
 	s := web.Server{
 		SearchSpace: wordDB,
 		WordTree:    wordDB,
+		Lexicons:    lexicons,
+		MoveBudget:  5 * time.Second,
 	}
+	if budget := os.Getenv("WORD_BOT_MOVE_BUDGET"); budget != "" {
+		s.MoveBudget, err = time.ParseDuration(budget)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if filename := os.Getenv("WORD_BOT_DEFINITIONS"); filename != "" {
+		store, err := definitions.LoadStoreFile(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s.Definitions = store
+	}
+	equity := ai.NewEquity(nil)
+	if filename := os.Getenv("WORD_BOT_LEAVES"); filename != "" {
+		equity.Leaves, err = ai.LoadLeaveValuesFile(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	s.Evaluators = map[string]ai.MoveEvaluator{"equity": equity}
+	// every /play request searches on the same workers
+	s.Engine = ai.NewEngine(0)
+	defer s.Engine.Close()
 	http.HandleFunc("/play", s.GetMove)
+	http.HandleFunc("/moves", s.MovesEndpoint)
+	http.HandleFunc("/analyze", s.AnalyzeEndpoint)
 	http.HandleFunc("/validate", s.ValidateEndpoint)
 	http.HandleFunc("/render", s.RenderBoard)
 	http.HandleFunc("/save", s.SaveGame)
+	http.HandleFunc("/lexicons", s.LexiconsEndpoint)
+	http.HandleFunc("/words/anagrams", s.AnagramsEndpoint)
+	http.HandleFunc("/words/pattern", s.PatternEndpoint)
+	http.HandleFunc("/words/containing", s.ContainingEndpoint)
+	http.HandleFunc("/words/hooks", s.HooksEndpoint)
 	http.Handle("/", http.FileServer(http.Dir("frontend/public")))
 
-	http.ListenAndServe(":"+os.Getenv("PORT"), nil)
+	// on a signal, finish the requests in flight before closing the engine
+	server := &http.Server{Addr: ":" + os.Getenv("PORT")}
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		<-stop
+		server.Shutdown(context.Background())
+	}()
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Println(err)
+		return
+	}
+	<-shutdown
 }
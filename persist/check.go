@@ -0,0 +1,89 @@
+package persist
+
+import (
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/jinzhu/gorm"
+)
+
+// InvalidPlay is a saved play which formed words missing from a word list
+type InvalidPlay struct {
+	GameID, MoveID uint
+	Player         string
+	Move           core.PlacedTiles
+	Words          []string
+}
+
+// LoadGames returns every saved game with its moves in the order they were made
+func (db *DB) LoadGames() ([]Game, error) {
+	var games []Game
+	err := db.DB.Preload("Moves", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Order("id").Find(&games).Error
+	if err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+// InvalidPlays checks the saved games played with one of lexicons against
+// wordList, see Game.InvalidPlays. An empty name matches the games saved
+// without their lexicon.
+func (db *DB) InvalidPlays(layout *core.BoardLayout, wordList core.WordList, lexicons ...string) ([]InvalidPlay, error) {
+	games, err := db.LoadGames()
+	if err != nil {
+		return nil, err
+	}
+	var invalid []InvalidPlay
+	for _, g := range games {
+		for _, lexicon := range lexicons {
+			if g.Lexicon == lexicon {
+				invalid = append(invalid, g.InvalidPlays(layout, wordList)...)
+				break
+			}
+		}
+	}
+	return invalid, nil
+}
+
+// InvalidPlays replays the game's plays onto an empty board, returning those
+// which form a word not in wordList. Phonies are skipped since they were
+// taken back.
+func (g Game) InvalidPlays(layout *core.BoardLayout, wordList core.WordList) []InvalidPlay {
+	board := core.NewBoard(layout)
+	var invalid []InvalidPlay
+	for _, m := range g.Moves {
+		if (m.Kind != KindPlay && m.Kind != "") || m.Tiles == "" {
+			continue
+		}
+		tiles, err := g.ParseTiles(m.Tiles)
+		if err != nil {
+			continue
+		}
+		move := core.PlacedTiles{
+			Word:      tiles,
+			Row:       m.Row,
+			Col:       m.Col,
+			Direction: m.Dir,
+		}
+		var words []string
+		for _, w := range board.FindNewWords(move) {
+			if len(w.Word) < 2 {
+				continue
+			}
+			if word := core.Tiles2Word(w.Word); !wordList.Contains(word) {
+				words = append(words, g.alphabet().WordString(word))
+			}
+		}
+		if len(words) > 0 {
+			invalid = append(invalid, InvalidPlay{
+				GameID: g.ID,
+				MoveID: m.ID,
+				Player: m.Player,
+				Move:   move,
+				Words:  words,
+			})
+		}
+		board.PlaceTiles(move)
+	}
+	return invalid
+}
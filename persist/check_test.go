@@ -0,0 +1,60 @@
+package persist
+
+import (
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/stretchr/testify/assert"
+)
+
+type wordSet map[string]bool
+
+func (w wordSet) Contains(word core.Word) bool {
+	return w[core.English.WordString(word)]
+}
+
+func TestInvalidPlays(t *testing.T) {
+	g := Game{Moves: []Move{
+		{ID: 1, Kind: KindPlay, Tiles: "caB", Row: 7, Col: 7, Dir: core.Horizontal, Player: "a"},
+		{ID: 2, Kind: KindPhony, Tiles: "zzz", Row: 0, Col: 0, Dir: core.Horizontal, Player: "b"},
+		{ID: 3, Tiles: "s", Row: 7, Col: 10, Dir: core.Vertical, Player: "b"},
+		{ID: 4, Kind: KindExchange, Tiles: "qq", Player: "a"},
+	}}
+
+	assert.Empty(t, g.InvalidPlays(core.ScrabbleLayout, wordSet{"cab": true, "cabs": true}))
+
+	invalid := g.InvalidPlays(core.ScrabbleLayout, wordSet{"cab": true})
+	assert.Equal(t, []InvalidPlay{{
+		MoveID: 3,
+		Player: "b",
+		Move:   core.PlacedTiles{Word: core.String2Tiles("s"), Row: 7, Col: 10, Direction: core.Vertical},
+		Words:  []string{"cabs"},
+	}}, invalid)
+}
+
+func TestDBInvalidPlaysByLexicon(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.DB.Close()
+
+	for _, lexicon := range []string{"", "default", "kids"} {
+		assert.NoError(t, db.SaveGame(Game{Lexicon: lexicon, Moves: []Move{
+			{Kind: KindPlay, Tiles: "cab", Row: 7, Col: 7, Dir: core.Horizontal, Player: "a"},
+		}}))
+	}
+	words := wordSet{}
+
+	invalid, err := db.InvalidPlays(core.ScrabbleLayout, words, "kids")
+	assert.NoError(t, err)
+	assert.Len(t, invalid, 1)
+
+	invalid, err = db.InvalidPlays(core.ScrabbleLayout, words, "default", "")
+	assert.NoError(t, err)
+	assert.Len(t, invalid, 2)
+
+	invalid, err = db.InvalidPlays(core.ScrabbleLayout, words, "sowpods")
+	assert.NoError(t, err)
+	assert.Empty(t, invalid)
+}
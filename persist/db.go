@@ -28,6 +28,7 @@ func NewDBConn(db *gorm.DB) (*DB, error) {
 	}, nil
 }
 
+// LeaveWeight is a tenth of the number of points keeping Leave is worth
 type LeaveWeight struct {
 	Leave  string
 	Weight float64
@@ -36,12 +37,40 @@ type LeaveWeight struct {
 type Game struct {
 	gorm.Model
 	Moves []Move
+	// Lexicon names the word list the game was played with. It is empty for
+	// games saved before lexicons were recorded.
+	Lexicon string
+	// Alphabet names the core.Alphabet tiles are written in, English if
+	// empty
+	Alphabet string
 }
 
+func (g Game) alphabet() *core.Alphabet {
+	if alphabet, ok := core.LookupAlphabet(g.Alphabet); ok {
+		return alphabet
+	}
+	return core.English
+}
+
+// ParseTiles reads the Tiles or Leave of one of the game's moves
+func (g Game) ParseTiles(text string) ([]core.Tile, error) {
+	return g.alphabet().ParseTiles(text)
+}
+
+// Kinds of rows in the moves table. Rows saved before Kind was added are plays.
+const (
+	KindPlay           = "play"
+	KindExchange       = "exchange"
+	KindPhony          = "phony"
+	KindChallenge      = "challenge"
+	KindRackAdjustment = "rack"
+)
+
 func (g *Game) AddMove(player string, leave []core.Tile, move core.ScoredMove) {
 	g.Moves = append(g.Moves, Move{
-		Tiles:  core.Tiles2String(move.Word),
-		Leave:  core.Tiles2String(leave),
+		Kind:   KindPlay,
+		Tiles:  g.alphabet().TilesString(move.Word),
+		Leave:  g.alphabet().TilesString(leave),
 		Row:    move.Row,
 		Col:    move.Col,
 		Player: player,
@@ -50,8 +79,51 @@ func (g *Game) AddMove(player string, leave []core.Tile, move core.ScoredMove) {
 	})
 }
 
+// AddExchange records an exchange. Tiles holds the tiles returned to the bag.
+func (g *Game) AddExchange(player string, leave []core.Tile, exchange core.Exchange) {
+	g.Moves = append(g.Moves, Move{
+		Kind:   KindExchange,
+		Tiles:  g.alphabet().TilesString(exchange.Tiles),
+		Leave:  g.alphabet().TilesString(leave),
+		Player: player,
+	})
+}
+
+// AddPhony records a move which was withdrawn after being challenged. It scores nothing.
+func (g *Game) AddPhony(player string, move core.ScoredMove) {
+	g.Moves = append(g.Moves, Move{
+		Kind:   KindPhony,
+		Tiles:  g.alphabet().TilesString(move.Word),
+		Row:    move.Row,
+		Col:    move.Col,
+		Player: player,
+		Dir:    move.Direction,
+	})
+}
+
+// AddChallengeAward records points given to a player for a failed challenge of their move
+func (g *Game) AddChallengeAward(player string, score core.Score) {
+	g.Moves = append(g.Moves, Move{
+		Kind:   KindChallenge,
+		Player: player,
+		Score:  score,
+	})
+}
+
+// AddRackAdjustment records the change to a player's score for the tiles
+// left on their rack at the end of the game.
+func (g *Game) AddRackAdjustment(player string, rack []core.Tile, score core.Score) {
+	g.Moves = append(g.Moves, Move{
+		Kind:   KindRackAdjustment,
+		Leave:  g.alphabet().TilesString(rack),
+		Player: player,
+		Score:  score,
+	})
+}
+
 type Move struct {
 	ID       uint `gorm:"primary_key"`
+	Kind     string
 	Tiles    string
 	Leave    string
 	Row, Col int
@@ -76,6 +148,22 @@ func (db *DB) LoadLeaveWeights() ([]LeaveWeight, error) {
 	return weights, nil
 }
 
+// SaveLeaveWeights replaces every saved leave weight with weights
+func (db *DB) SaveLeaveWeights(weights []LeaveWeight) error {
+	tx := db.DB.Begin()
+	if err := tx.Delete(LeaveWeight{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, w := range weights {
+		if err := tx.Create(&w).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
 type Matchup struct {
 	Player1, Player2 string
 	NumGames         int
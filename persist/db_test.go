@@ -0,0 +1,41 @@
+package persist
+
+import (
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveLeaveWeights(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.DB.Close()
+
+	assert.NoError(t, db.SaveLeaveWeights([]LeaveWeight{{Leave: "qu", Weight: -5}, {Leave: "s", Weight: 8}}))
+	assert.NoError(t, db.SaveLeaveWeights([]LeaveWeight{{Leave: "A", Weight: 25}, {Leave: "s", Weight: 7.5}}))
+
+	weights, err := db.LoadLeaveWeights()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []LeaveWeight{{Leave: "A", Weight: 25}, {Leave: "s", Weight: 7.5}}, weights)
+}
+
+func TestGameTilesUseAlphabet(t *testing.T) {
+	word := core.Spanish.MustParse("ñu")
+	tiles := []core.Tile{word[0].ToTile(false), word[1].ToTile(true)}
+	g := Game{Alphabet: core.Spanish.Name}
+	g.AddMove("a", tiles[:1], core.ScoredMove{PlacedTiles: core.PlacedTiles{Word: tiles}})
+	assert.Equal(t, "ñU", g.Moves[0].Tiles)
+	assert.Equal(t, "ñ", g.Moves[0].Leave)
+
+	parsed, err := g.ParseTiles(g.Moves[0].Tiles)
+	assert.NoError(t, err)
+	assert.Equal(t, tiles, parsed)
+
+	_, err = Game{}.ParseTiles("ñ")
+	assert.Error(t, err, "games default to English")
+}
@@ -5,7 +5,7 @@ import (
 )
 
 func main() {
-	b := core.NewBoard()
+	b := core.NewBoard(core.ScrabbleLayout)
 	b.PlaceTiles(core.PlacedTiles{
 		Col:  7, Row: 7, Direction: core.Horizontal,
 		Word: core.MakeTiles(core.MakeWord("hello"), "xxxxx"),
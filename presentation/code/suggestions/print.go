@@ -1,6 +1,7 @@
 package suggestions
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -9,7 +10,7 @@ import (
 )
 
 func PrintSuggestions(player ai.AI) {
-	b := core.NewBoard()
+	b := core.NewBoard(core.ScrabbleLayout)
 	b.StoreValidatedMoves = true
 	b.PlaceTiles(core.PlacedTiles{
 		Col:  7, Row: 7, Direction: core.Horizontal,
@@ -19,9 +20,9 @@ func PrintSuggestions(player ai.AI) {
 		Col:  11, Row: 6, Direction: core.Vertical,
 		Word: core.MakeTiles(core.MakeWord("wrld"), "xxxx"),
 	})
-	bag := core.NewConsumableBag()
+	bag := core.NewConsumableBag(core.EnglishScrabble)
 	rack := core.NewConsumableRack(core.MakeTiles(core.MakeWord("abc"), "xxx"))
-	player.FindMove(b, bag, rack, func(turn core.Turn) bool {
+	player.FindMove(context.Background(), b, bag, rack, func(turn core.Turn) bool {
 		return true
 	})
 	numIterations := 10
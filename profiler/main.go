@@ -11,6 +11,7 @@ import (
 )
 
 var wordDB = wordlist.MakeDefaultWordList()
+var engine = ai.NewEngine(0)
 
 func main() {
 	defer profile.Start(profile.CPUProfile).Stop()
@@ -24,11 +25,11 @@ func main() {
 }
 
 func smarty(board *core.Board) *ai.Player {
-	return ai.NewPlayer(ai.NewSmartyAI(wordDB, wordDB))
+	return ai.NewPlayer(engine.NewSmartyAI(wordDB, wordDB))
 }
 
 func speedy(board *core.Board) *ai.Player {
-	return ai.NewPlayer(ai.NewSmartyAI(wordDB, wordDB))
+	return ai.NewPlayer(engine.NewSmartyAI(wordDB, wordDB))
 }
 
 func brute(board *core.Board) *ai.Player {
@@ -1,138 +1,84 @@
 package smarter
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
 	"github.com/Logiraptor/word-bot/ai"
 	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/core/game"
 	mcts "github.com/glemzurg/go-mcts"
 )
 
+// GameState is a node in the search tree. Player 0 is the AI searching for a
+// move, player 1's rack is unknown and drawn at random from the bag.
 type GameState struct {
-	opponentTurn bool
-	moveGen      ai.MoveGenerator
-	lastPlay     core.ScoredMove
-	board        *core.Board
-	rack         core.Rack
-	opponentRack core.Rack
-	bag          core.Bag
+	moveGen ai.MoveGenerator
+	state   *game.State
 }
 
 func (g *GameState) String() string {
-	rack := g.rack
-	if g.opponentTurn {
-		rack = g.opponentRack
+	var lastPlay core.Turn
+	if n := len(g.state.History); n > 0 {
+		lastPlay = g.state.History[n-1].Turn
 	}
-	return fmt.Sprintf("%t => %s => %s", g.opponentTurn, g.lastPlay, core.Tiles2String(rack.Rack))
+	return fmt.Sprintf("%d => %s => %s", g.state.Current, lastPlay, core.Tiles2String(g.state.CurrentPlayer().Rack.Rack))
 }
 
 var _ mcts.GameState = &GameState{}
 
 func (g *GameState) AvailableMoves() []mcts.Move {
-	// Run smarty
-	var rack = g.rack
-	if g.opponentTurn {
-		rack = g.opponentRack
+	if g.state.IsOver() {
+		return nil
 	}
 
-	moves := []mcts.Move{Move{Turn: core.Pass{}}, Move{Turn: core.Exchange{}}}
-	g.moveGen.GenerateMoves(g.board, rack, func(turn core.Turn) bool {
-		moves = append(moves, Move{
-			Turn: turn,
-		})
+	// Run smarty
+	rack := g.state.CurrentPlayer().Rack
+	var plays []core.ScoredMove
+	g.moveGen.GenerateMoves(context.Background(), g.state.Board, rack, func(turn core.Turn) bool {
+		if sm, ok := turn.(core.ScoredMove); ok {
+			plays = append(plays, sm)
+		}
 		return true
 	})
-
-	sort.Slice(moves, func(i, j int) bool {
-		x := moves[i].(Move).Turn
-		y := moves[j].(Move).Turn
-		if _, ok := x.(core.Pass); ok {
-			return true
-		}
-		if _, ok := x.(core.Exchange); ok {
-			return true
-		}
-		if _, ok := y.(core.Pass); ok {
-			return true
-		}
-		if _, ok := y.(core.Exchange); ok {
-			return true
-		}
-		smX := x.(core.ScoredMove)
-		smY := y.(core.ScoredMove)
-		return smX.Score > smY.Score
+	sort.Slice(plays, func(i, j int) bool {
+		return plays[i].Score > plays[j].Score
 	})
 	// return moves from smarty
-	if len(moves) > 10 {
-		moves = moves[:10]
+	if len(plays) > 10 {
+		plays = plays[:10]
+	}
+
+	// only the whole rack is considered for exchange to keep the tree narrow
+	moves := []mcts.Move{Move{Turn: core.Pass{}}}
+	if rack.ValidateExchange(core.Exchange{Tiles: rack.Rack}, g.state.Bag) == nil {
+		moves = append(moves, Move{Turn: core.Exchange{Tiles: rack.Rack}})
+	}
+	for _, play := range plays {
+		moves = append(moves, Move{Turn: play})
 	}
 	return moves
 }
 
 func (g *GameState) Clone() mcts.GameState {
 	return &GameState{
-		opponentTurn: g.opponentTurn,
-		moveGen:      g.moveGen,
-		board:        g.board.Clone(),
-		rack:         g.rack,
-		opponentRack: g.opponentRack,
-		bag:          g.bag,
+		moveGen: g.moveGen,
+		state:   g.state.Clone(),
 	}
 }
 
 func (g *GameState) MakeMove(m mcts.Move) {
-	g.opponentTurn = !g.opponentTurn
-	switch v := m.(Move).Turn.(type) {
-	case core.ScoredMove:
-		if g.opponentTurn {
-			if rack, ok := g.rack.Play(v.Word); ok {
-				g.lastPlay = v
-				g.board.PlaceTiles(v.PlacedTiles)
-				g.rack = rack
-			} else {
-				fmt.Println("Cannot play the tiles")
-			}
-		} else {
-			if rack, ok := g.opponentRack.Play(v.Word); ok {
-				g.lastPlay = v
-				g.board.PlaceTiles(v.PlacedTiles)
-				g.opponentRack = rack
-			} else {
-				fmt.Println("Cannot play the tiles")
-			}
-		}
-
-		return
-	case core.Exchange:
-		newRack := core.NewConsumableRack(nil)
-		g.bag, newRack.Rack = g.bag.FillRack(newRack.Rack, 7-len(newRack.Rack))
-		if g.opponentTurn {
-			g.bag = g.bag.Replace(g.opponentRack.Rack)
-			g.opponentRack = newRack
-			g.bag, g.opponentRack.Rack = g.bag.FillRack(g.opponentRack.Rack, 7-len(g.opponentRack.Rack))
-		} else {
-			g.bag = g.bag.Replace(g.rack.Rack)
-			g.rack = newRack
-			g.bag, g.rack.Rack = g.bag.FillRack(g.rack.Rack, 7-len(g.rack.Rack))
-		}
-
-		return
-	case core.Pass:
-		return
-	default:
-		panic(fmt.Sprintf("Smarter cannot make move %#v", v))
+	if _, err := g.state.Play(m.(Move).Turn); err != nil {
+		fmt.Println("Smarter cannot make move:", err)
 	}
 }
 
 func (g *GameState) RandomizeUnknowns() {
-	g.bag = g.bag.Shuffle()
-	if g.opponentTurn {
-		g.bag, g.rack.Rack = g.bag.FillRack(g.rack.Rack, 7-len(g.rack.Rack))
-		g.bag, g.opponentRack.Rack = g.bag.FillRack(g.opponentRack.Rack, 7-len(g.opponentRack.Rack))
-	} else {
-		g.bag, g.opponentRack.Rack = g.bag.FillRack(g.opponentRack.Rack, 7-len(g.opponentRack.Rack))
-		g.bag, g.rack.Rack = g.bag.FillRack(g.rack.Rack, 7-len(g.rack.Rack))
+	g.state.Bag = g.state.Bag.Shuffle()
+	for i := range g.state.Players {
+		p := g.state.Players[(g.state.Current+1+i)%len(g.state.Players)]
+		g.state.Bag, p.Rack.Rack = g.state.Bag.FillRack(p.Rack.Rack, g.state.Bag.TileSet().RackSize-len(p.Rack.Rack))
 	}
 }
 
@@ -166,16 +112,24 @@ func NewMCTSAI(moveGen ai.MoveGenerator, eval ai.BoardEvaluator, iterations, sim
 
 var _ ai.AI = &MCTSAI{}
 
-func (m *MCTSAI) FindMove(board *core.Board, bag core.Bag, rack core.Rack, callback func(core.Turn) bool) {
+// FindMove runs the whole search, so ctx is only checked before starting
+func (m *MCTSAI) FindMove(ctx context.Context, board *core.Board, bag core.Bag, rack core.Rack, callback func(core.Turn) bool) {
+	if ctx.Err() != nil {
+		return
+	}
 
-	r := core.NewConsumableRack(nil)
+	state := &game.State{
+		Board: board,
+		Bag:   bag,
+		Players: []*game.Player{
+			{Name: m.Name(), Rack: rack},
+			{Name: "opponent", Rack: core.NewConsumableRack(nil)},
+		},
+	}
 
 	move := mcts.Uct(&GameState{
-		board:        board,
-		rack:         rack,
-		moveGen:      m.moveGen,
-		opponentRack: r,
-		bag:          bag,
+		moveGen: m.moveGen,
+		state:   state,
 	}, m.iterations, m.simulations, m.bias, 0, m.Score)
 	callback(move.(Move).Turn)
 }
@@ -187,11 +141,9 @@ func (m *MCTSAI) Name() string {
 // Score the game from playerIds perspective
 func (m *MCTSAI) Score(playerId uint64, s mcts.GameState) float64 {
 	gs := s.(*GameState)
-	score := 0.0
-	if gs.opponentTurn {
-		score = -m.eval.Evaluate(gs.board, gs.bag, gs.opponentRack, gs.rack)
-	} else {
-		score = m.eval.Evaluate(gs.board, gs.bag, gs.rack, gs.opponentRack)
+	us, them := gs.state.Players[0], gs.state.Players[1]
+	if gs.state.Current == 1 {
+		return -m.eval.Evaluate(gs.state.Board, gs.state.Bag, them.Rack, us.Rack)
 	}
-	return score
+	return m.eval.Evaluate(gs.state.Board, gs.state.Bag, us.Rack, them.Rack)
 }
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+)
+
+const maxSimCandidates = 20
+
+// AnalyzeRequest asks for the best moves for the rack to be simulated. Count
+// candidates are chosen by Evaluator as in MovesRequest, and Spread is how
+// many points the player is ahead by.
+type AnalyzeRequest struct {
+	MovesRequest
+	Spread core.Score `json:"spread"`
+}
+
+// SimResultJS is what simulating a move found, see ai.SimResult
+type SimResultJS struct {
+	RankedMoveJS
+	Iterations int     `json:"iterations"`
+	Equity     float64 `json:"equity"`
+	StdDev     float64 `json:"stdDev"`
+	WinRate    float64 `json:"winRate"`
+}
+
+// AnalyzeEndpoint simulates the best moves for the rack and lists them by
+// their simulated equity, best first. The tiles the player can't see are
+// drawn from in the simulations. The simulation is limited by MoveBudget
+// like GetMove.
+func (s Server) AnalyzeEndpoint(rw http.ResponseWriter, req *http.Request) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println(r)
+		}
+	}()
+	var analyze AnalyzeRequest
+	err := json.NewDecoder(req.Body).Decode(&analyze)
+	if err != nil {
+		http.Error(rw, "JSON parsing failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if analyze.Count <= 0 {
+		analyze.Count = defaultMoveCount
+	}
+	if analyze.Count > maxSimCandidates {
+		analyze.Count = maxSimCandidates
+	}
+	eval, ok := s.evaluator(analyze.Evaluator)
+	if !ok {
+		http.Error(rw, fmt.Sprintf("Unknown evaluator %q", analyze.Evaluator), http.StatusBadRequest)
+		return
+	}
+
+	wordList, wordTree, err := s.boardLexicon(analyze.Lexicon)
+	if err != nil {
+		lexiconError(rw, err)
+		return
+	}
+
+	state, _ := replay(nil, analyze.MoveRequest)
+
+	ctx := req.Context()
+	if s.MoveBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.MoveBudget)
+		defer cancel()
+	}
+	sim := ai.NewSimulator(ai.NewAnchorAI(wordList, wordTree), eval)
+	sim.Candidates = analyze.Count
+	rack := core.NewConsumableRack(jsTilesToTiles(analyze.Rack))
+	results := sim.Simulate(ctx, state.Board, state.Bag, rack, analyze.Spread)
+
+	output := make([]SimResultJS, len(results))
+	for i, r := range results {
+		output[i] = SimResultJS{
+			RankedMoveJS: rankedMove2JS(r.RankedMove),
+			Iterations:   r.Iterations,
+			Equity:       r.Equity,
+			StdDev:       r.StdDev,
+			WinRate:      r.WinRate,
+		}
+	}
+	json.NewEncoder(rw).Encode(output)
+}
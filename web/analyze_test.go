@@ -0,0 +1,38 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Logiraptor/word-bot/wordlist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeEndpoint(t *testing.T) {
+	wordDB := wordlist.MakeDefaultWordList()
+	s := Server{SearchSpace: wordDB, WordTree: wordDB, MoveBudget: 2 * time.Second}
+	rack := `"rack": [{"letter": "r"}, {"letter": "e"}, {"letter": "t"}, {"letter": "a"}, {"letter": "i"}, {"letter": "n"}, {"letter": "s"}]`
+
+	var results []SimResultJS
+	rw := httptest.NewRecorder()
+	s.AnalyzeEndpoint(rw, httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(`{"moves": [], `+rack+`, "count": 3, "spread": 500}`)))
+	assert.NoError(t, json.NewDecoder(rw.Body).Decode(&results))
+	if assert.Len(t, results, 3) {
+		for i, r := range results {
+			if i > 0 {
+				assert.True(t, results[i-1].Equity >= r.Equity, "results are out of order")
+			}
+			assert.Len(t, r.Tiles, 7)
+			assert.True(t, r.Iterations > 0)
+			assert.Equal(t, 1.0, r.WinRate)
+		}
+	}
+
+	rw = httptest.NewRecorder()
+	s.AnalyzeEndpoint(rw, httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(`{"moves": [], `+rack+`, "evaluator": "psychic"}`)))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
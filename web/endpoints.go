@@ -1,13 +1,18 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Logiraptor/word-bot/ai"
 	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/core/game"
+	"github.com/Logiraptor/word-bot/definitions"
 	"github.com/Logiraptor/word-bot/wordlist"
 )
 
@@ -15,10 +20,24 @@ type DB interface {
 	Save([]core.PlacedTiles) error
 }
 
+// Server answers requests with the lexicon they name from Lexicons, or
+// with WordTree and SearchSpace if they don't name one.
 type Server struct {
 	WordTree    *wordlist.Trie
 	SearchSpace core.WordList
-	DB          DB
+	Lexicons    *wordlist.Registry
+	// Definitions, if set, define the words formed by validated and chosen moves
+	Definitions definitions.Source
+	// MoveBudget, if positive, limits how long GetMove searches. The best
+	// move found in time is played.
+	MoveBudget time.Duration
+	// Engine, if set, runs GetMove's searches on its workers, so concurrent
+	// requests share a bounded pool. Otherwise each search starts its own.
+	Engine *ai.Engine
+	// Evaluators rank the moves listed by MovesEndpoint and AnalyzeEndpoint,
+	// by name. Moves can always be ranked by "score".
+	Evaluators map[string]ai.MoveEvaluator
+	DB         DB
 }
 
 type AI interface {
@@ -30,8 +49,9 @@ func toTiles(word string) []core.Tile {
 }
 
 type MoveRequest struct {
-	Moves []Move   `json:"moves"`
-	Rack  []TileJS `json:"rack"`
+	Moves   []Move   `json:"moves"`
+	Rack    []TileJS `json:"rack"`
+	Lexicon string   `json:"lexicon,omitempty"`
 }
 
 type TileJS struct {
@@ -71,15 +91,23 @@ func (m Move) ToPlacedTiles() core.PlacedTiles {
 }
 
 type ScoredMoveJS struct {
-	Tiles []TileJS   `json:"tiles"`
-	Row   int        `json:"row"`
-	Col   int        `json:"col"`
-	Dir   string     `json:"direction"` // vertical / horizontal
-	Score core.Score `json:"score"`
+	Tiles       []TileJS       `json:"tiles"`
+	Row         int            `json:"row"`
+	Col         int            `json:"col"`
+	Dir         string         `json:"direction"` // vertical / horizontal
+	Score       core.Score     `json:"score"`
+	Definitions []DefinitionJS `json:"definitions,omitempty"`
+}
+
+// DefinitionJS defines a word formed by a move. Definition is empty if the
+// word couldn't be defined.
+type DefinitionJS struct {
+	Word       string `json:"word"`
+	Definition string `json:"definition,omitempty"`
 }
 
 type RenderedBoard struct {
-	Board  [15][15]TileJS
+	Board  [][]TileJS
 	Scores []core.Score
 }
 
@@ -111,8 +139,8 @@ func tiles2JsTiles(tiles []core.Tile) []TileJS {
 func tile2JsTile(t core.Tile) TileJS {
 	tJS := TileJS{
 		Blank:  t.IsBlank(),
-		Letter: string(t.ToRune()),
-		Value:  t.PointValue(),
+		Letter: core.English.LetterString(t.ToLetter()),
+		Value:  t.PointValue(core.EnglishScrabble),
 		Flags:  []uint{},
 	}
 	for i := uint(0); i < 8; i++ {
@@ -136,36 +164,46 @@ func (s Server) GetMove(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	b := core.NewBoard()
-	bag := core.NewConsumableBag()
-	bag = bag.ConsumeTiles(jsTilesToTiles(moves.Rack))
-	for _, move := range moves.Moves {
-		pt := move.ToPlacedTiles()
-		b.PlaceTiles(pt)
-		bag = bag.ConsumeTiles(pt.Word)
+	wordList, wordTree, err := s.boardLexicon(moves.Lexicon)
+	if err != nil {
+		lexiconError(rw, err)
+		return
 	}
 
-	ai := ai.NewSmartyAI(s.SearchSpace, s.WordTree)
-	defer ai.Kill()
+	state, _ := replay(nil, moves)
+
+	ctx := req.Context()
+	if s.MoveBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.MoveBudget)
+		defer cancel()
+	}
+	var bot *ai.SmartyAI
+	if s.Engine != nil {
+		bot = s.Engine.NewSmartyAI(wordList, wordTree)
+	} else {
+		bot = ai.NewSmartyAI(wordList, wordTree)
+	}
+	defer bot.Kill()
 	var play core.ScoredMove
-	ai.FindMove(b, bag, core.NewConsumableRack(jsTilesToTiles(moves.Rack)), func(turn core.Turn) bool {
+	bot.FindMove(ctx, state.Board, state.Bag, core.NewConsumableRack(jsTilesToTiles(moves.Rack)), func(turn core.Turn) bool {
 		if sm, ok := turn.(core.ScoredMove); ok {
 			play = sm
 		}
 		return true
 	})
 
-	dirString := "horizontal"
-	if play.Direction == core.Vertical {
-		dirString = "vertical"
+	var defined []DefinitionJS
+	if len(play.Word) > 0 {
+		defined = s.define(state.Board.FindNewWords(play.PlacedTiles))
 	}
-
 	json.NewEncoder(rw).Encode(ScoredMoveJS{
-		Tiles: tiles2JsTiles(play.Word),
-		Row:   play.Row,
-		Col:   play.Col,
-		Dir:   dirString,
-		Score: play.Score,
+		Tiles:       tiles2JsTiles(play.Word),
+		Row:         play.Row,
+		Col:         play.Col,
+		Dir:         dirString(play.Direction),
+		Score:       play.Score,
+		Definitions: defined,
 	})
 }
 
@@ -212,26 +250,48 @@ func (s Server) SaveGame(rw http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// replayedMove is the outcome of replaying one requested move
+type replayedMove struct {
+	err   error
+	words []core.PlacedTiles
+}
+
+// replay plays the requested moves onto a new game. The bag is left holding
+// the tiles which are neither on the board nor on the rack. If wordList is
+// non-nil the reason each illegal move is invalid is returned.
+func replay(wordList core.WordList, moves MoveRequest) (*game.State, []replayedMove) {
+	state := game.New(wordList, core.NewBoard(core.ScrabbleLayout), core.NewConsumableBag(core.EnglishScrabble))
+	replayed := make([]replayedMove, len(moves.Moves))
+	for i, m := range moves.Moves {
+		move := m.ToPlacedTiles()
+		replayed[i].words = state.Board.FindNewWords(move)
+		_, replayed[i].err = state.Record(move)
+	}
+	state.Bag = state.Bag.ConsumeTiles(jsTilesToTiles(moves.Rack))
+	return state, replayed
+}
+
 func Render(moves MoveRequest) RenderedBoard {
 	var output RenderedBoard
-	output.Scores = make([]core.Score, len(moves.Moves))
-	b := core.NewBoard()
-	for i, move := range moves.Moves {
-		pt := move.ToPlacedTiles()
-		output.Scores[i] = b.Score(pt)
-		b.PlaceTiles(pt)
-	}
-
-	for i, row := range b.Cells {
-		for j, cell := range row {
-			if !cell.Tile.IsNoTile() {
-				output.Board[i][j] = tile2JsTile(cell.Tile)
+	state, _ := replay(nil, moves)
+	output.Scores = make([]core.Score, len(state.History))
+	for i, event := range state.History {
+		output.Scores[i] = event.Score
+	}
+
+	b := state.Board
+	output.Board = make([][]TileJS, b.Layout.Rows)
+	for i := range output.Board {
+		output.Board[i] = make([]TileJS, b.Layout.Cols)
+		for j := range output.Board[i] {
+			if tile := b.TileAt(i, j); !tile.IsNoTile() {
+				output.Board[i][j] = tile2JsTile(tile)
 			} else {
 				output.Board[i][j] = TileJS{
 					Blank:  true,
 					Letter: "",
 					Value:  -1,
-					Bonus:  cell.Bonus.ToString(),
+					Bonus:  b.BonusAt(i, j).ToString(),
 				}
 			}
 		}
@@ -252,27 +312,186 @@ func (s Server) ValidateEndpoint(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	var output = s.Validate(moves)
+	output, err := s.Validate(moves)
+	if err != nil {
+		lexiconError(rw, err)
+		return
+	}
 
 	json.NewEncoder(rw).Encode(output)
 }
 
-func (s Server) Validate(moves MoveRequest) []bool {
-	output := make([]bool, len(moves.Moves))
-	b := core.NewBoard()
-	for i, m := range moves.Moves {
-		output[i] = b.ValidateMove(m.ToPlacedTiles(), s.SearchSpace)
-		b.PlaceTiles(m.ToPlacedTiles())
+// Validation explains whether a move is legal and, if not, why. Definitions
+// define each word the move forms.
+type Validation struct {
+	Valid       bool           `json:"valid"`
+	Reason      string         `json:"reason,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	Words       []WordJS       `json:"words,omitempty"`
+	Definitions []DefinitionJS `json:"definitions,omitempty"`
+}
+
+// WordJS is a word on the board, used to point at invalid words
+type WordJS struct {
+	Word string `json:"word"`
+	Row  int    `json:"row"`
+	Col  int    `json:"col"`
+	Dir  string `json:"direction"`
+}
+
+// Validate explains whether each move is legal in the requested lexicon
+func (s Server) Validate(moves MoveRequest) ([]Validation, error) {
+	wordList, _, err := s.boardLexicon(moves.Lexicon)
+	if err != nil {
+		return nil, err
+	}
+	_, replayed := replay(wordList, moves)
+	output := make([]Validation, len(replayed))
+	for i, move := range replayed {
+		output[i] = newValidation(move.err)
+		output[i].Definitions = s.define(move.words)
+	}
+	return output, nil
+}
+
+// define looks up the given words, returning nil if there is no definitions source
+func (s Server) define(words []core.PlacedTiles) []DefinitionJS {
+	if s.Definitions == nil {
+		return nil
+	}
+	output := make([]DefinitionJS, len(words))
+	for i, w := range words {
+		output[i].Word = core.English.WordString(core.Tiles2Word(w.Word))
+		output[i].Definition, _ = s.Definitions.Define(output[i].Word)
 	}
 	return output
 }
 
-func RemainingTiles(moves MoveRequest) []TileJS {
-	b := core.NewConsumableBag()
-	for _, m := range moves.Moves {
-		b = b.ConsumeTiles(m.ToPlacedTiles().Word)
+func newValidation(err error) Validation {
+	if err == nil {
+		return Validation{Valid: true}
+	}
+	v := Validation{Error: err.Error()}
+	if moveErr, ok := err.(*core.InvalidMoveError); ok {
+		v.Reason = moveErr.Reason.String()
+		for _, w := range moveErr.Words {
+			v.Words = append(v.Words, WordJS{
+				Word: core.Tiles2String(w.Word),
+				Row:  w.Row,
+				Col:  w.Col,
+				Dir:  dirString(w.Direction),
+			})
+		}
+	}
+	return v
+}
+
+func dirString(dir core.Direction) string {
+	if dir == core.Vertical {
+		return "vertical"
 	}
-	b = b.ConsumeTiles(jsTilesToTiles(moves.Rack))
-	var remaining []core.Tile = b.Remaining()
+	return "horizontal"
+}
+
+func RemainingTiles(moves MoveRequest) []TileJS {
+	state, _ := replay(nil, moves)
+	var remaining []core.Tile = state.Bag.Remaining()
 	return tiles2JsTiles(remaining)
 }
+
+// lexicon returns the word lists of the named lexicon, loading it if needed
+func (s Server) lexicon(name string) (core.WordList, *wordlist.Trie, error) {
+	if name == "" {
+		return s.SearchSpace, s.WordTree, nil
+	}
+	if s.Lexicons == nil {
+		return nil, nil, &wordlist.UnknownLexiconError{Name: name}
+	}
+	lexicon, err := s.Lexicons.Lookup(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	trie, err := lexicon.Trie()
+	if err != nil {
+		return nil, nil, err
+	}
+	return lexicon, trie, nil
+}
+
+// boardLexicon finds a lexicon for the board endpoints. Boards are played
+// with English tiles, so lexicons spelled in other alphabets are refused.
+func (s Server) boardLexicon(name string) (core.WordList, *wordlist.Trie, error) {
+	wordList, trie, err := s.lexicon(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lexicon, ok := wordList.(*wordlist.Lexicon); ok && lexicon.Alphabet != core.English {
+		return nil, nil, &alphabetError{lexicon: lexicon}
+	}
+	return wordList, trie, nil
+}
+
+type alphabetError struct {
+	lexicon *wordlist.Lexicon
+}
+
+func (e *alphabetError) Error() string {
+	return fmt.Sprintf("lexicon %q is spelled in the %s alphabet, boards only support english", e.lexicon.Name, e.lexicon.Alphabet.Name)
+}
+
+func lexiconError(rw http.ResponseWriter, err error) {
+	switch err.(type) {
+	case *wordlist.UnknownLexiconError, *alphabetError:
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(rw, "Loading lexicon failed: "+err.Error(), http.StatusInternalServerError)
+}
+
+// maxLexiconBytes limits the size of word lists posted to LexiconsEndpoint
+const maxLexiconBytes = 16 << 20
+
+// LexiconsEndpoint lists the available lexicons on GET. On POST it adds the
+// newline separated word list in the body as the lexicon given by the name
+// query parameter, spelled in the alphabet parameter (default english).
+// Lexicons which are already registered can't be replaced.
+func (s Server) LexiconsEndpoint(rw http.ResponseWriter, req *http.Request) {
+	if s.Lexicons == nil {
+		http.Error(rw, "Lexicons are not enabled", http.StatusNotFound)
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		json.NewEncoder(rw).Encode(s.Lexicons.Names())
+	case http.MethodPost:
+		name := req.URL.Query().Get("name")
+		if name == "" {
+			http.Error(rw, "Missing lexicon name", http.StatusBadRequest)
+			return
+		}
+		alphabet := core.English
+		if alphabetName := req.URL.Query().Get("alphabet"); alphabetName != "" {
+			var ok bool
+			if alphabet, ok = core.LookupAlphabet(alphabetName); !ok {
+				http.Error(rw, fmt.Sprintf("Unknown alphabet %q", alphabetName), http.StatusBadRequest)
+				return
+			}
+		}
+		words, err := ioutil.ReadAll(http.MaxBytesReader(rw, req.Body, maxLexiconBytes))
+		if err != nil {
+			status := http.StatusBadRequest
+			if len(words) >= maxLexiconBytes {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(rw, "Reading word list failed: "+err.Error(), status)
+			return
+		}
+		if err := s.Lexicons.Add(wordlist.WordsLexicon(name, alphabet, words)); err != nil {
+			http.Error(rw, err.Error(), http.StatusConflict)
+			return
+		}
+		rw.WriteHeader(http.StatusCreated)
+	default:
+		http.Error(rw, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
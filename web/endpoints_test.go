@@ -1,8 +1,20 @@
 package web
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/definitions"
+	"github.com/Logiraptor/word-bot/internal/leaktest"
+	"github.com/Logiraptor/word-bot/wordlist"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,3 +46,157 @@ func TestRenderWithTags(t *testing.T) {
 	assert.EqualValues(t, board.Board[7][7].Flags, []uint{0})
 	assert.EqualValues(t, board.Board[7][8].Flags, []uint{1})
 }
+
+func TestValidateReasons(t *testing.T) {
+	s := Server{SearchSpace: fakeWordList{"cab"}}
+	validations, err := s.Validate(MoveRequest{
+		Moves: []Move{
+			{Row: 7, Col: 7, Dir: "horizontal", Tiles: []TileJS{{Letter: "c"}, {Letter: "a"}, {Letter: "b"}}},
+			{Row: 6, Col: 8, Dir: "vertical", Tiles: []TileJS{{Letter: "x"}}},
+			{Row: 0, Col: 0, Dir: "vertical", Tiles: []TileJS{{Letter: "a"}, {Letter: "b"}}},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Validation{
+		{Valid: true},
+		{
+			Reason: "invalid words",
+			Error:  "(6,8,Vertical: x): invalid words (6,8,Vertical: xa)",
+			Words:  []WordJS{{Word: "xa", Row: 6, Col: 8, Dir: "vertical"}},
+		},
+		{
+			Reason: "not connected to other words",
+			Error:  "(0,0,Vertical: ab): not connected to other words",
+		},
+	}, validations)
+}
+
+type fakeWordList []string
+
+func (f fakeWordList) Contains(word core.Word) bool {
+	for _, w := range f {
+		if reflect.DeepEqual(core.MakeWord(w), word) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateWithLexicon(t *testing.T) {
+	s := Server{
+		SearchSpace: fakeWordList{},
+		Lexicons: wordlist.NewRegistry(
+			wordlist.WordsLexicon("cabs", core.English, []byte("cab\n")),
+			wordlist.WordsLexicon("cabos", core.Spanish, []byte("cabo\n")),
+		),
+	}
+	cab := []Move{{Row: 7, Col: 7, Dir: "horizontal", Tiles: []TileJS{{Letter: "c"}, {Letter: "a"}, {Letter: "b"}}}}
+
+	validations, err := s.Validate(MoveRequest{Moves: cab})
+	assert.NoError(t, err)
+	assert.False(t, validations[0].Valid)
+
+	validations, err = s.Validate(MoveRequest{Moves: cab, Lexicon: "cabs"})
+	assert.NoError(t, err)
+	assert.True(t, validations[0].Valid)
+
+	_, err = s.Validate(MoveRequest{Moves: cab, Lexicon: "sowpods"})
+	assert.EqualError(t, err, `unknown lexicon "sowpods"`)
+
+	_, err = s.Validate(MoveRequest{Moves: cab, Lexicon: "cabos"})
+	assert.Error(t, err, "boards are played with english tiles")
+}
+
+func TestValidateDefinitions(t *testing.T) {
+	s := Server{
+		SearchSpace: fakeWordList{"cab", "cabs", "sh"},
+		Definitions: definitions.Store{"cab": "a taxi", "cabs": "more than one taxi"},
+	}
+	validations, err := s.Validate(MoveRequest{
+		Moves: []Move{
+			{Row: 7, Col: 7, Dir: "horizontal", Tiles: []TileJS{{Letter: "c"}, {Letter: "a"}, {Letter: "b"}}},
+			{Row: 7, Col: 10, Dir: "vertical", Tiles: []TileJS{{Letter: "s"}, {Letter: "h"}}},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []DefinitionJS{{Word: "cab", Definition: "a taxi"}}, validations[0].Definitions)
+	assert.ElementsMatch(t, []DefinitionJS{
+		{Word: "cabs", Definition: "more than one taxi"},
+		{Word: "sh"},
+	}, validations[1].Definitions)
+}
+
+func TestLexiconsEndpoint(t *testing.T) {
+	s := Server{Lexicons: wordlist.NewRegistry(wordlist.Default)}
+
+	rw := httptest.NewRecorder()
+	s.LexiconsEndpoint(rw, httptest.NewRequest(http.MethodPost, "/lexicons?name=kids", strings.NewReader("cat\n")))
+	assert.Equal(t, http.StatusCreated, rw.Code)
+
+	rw = httptest.NewRecorder()
+	s.LexiconsEndpoint(rw, httptest.NewRequest(http.MethodGet, "/lexicons", nil))
+	assert.JSONEq(t, `["default", "kids"]`, rw.Body.String())
+
+	for _, name := range []string{"kids", wordlist.Default.Name} {
+		rw = httptest.NewRecorder()
+		s.LexiconsEndpoint(rw, httptest.NewRequest(http.MethodPost, "/lexicons?name="+name, strings.NewReader("dog\n")))
+		assert.Equal(t, http.StatusConflict, rw.Code, "replaced %s", name)
+	}
+	lexicon, _ := s.Lexicons.Lookup("kids")
+	assert.True(t, lexicon.Contains(core.MakeWord("cat")))
+
+	rw = httptest.NewRecorder()
+	s.LexiconsEndpoint(rw, httptest.NewRequest(http.MethodPost, "/lexicons?name=huge", strings.NewReader(strings.Repeat("a", maxLexiconBytes+1))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+
+	rw = httptest.NewRecorder()
+	s.ValidateEndpoint(rw, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"moves": [], "lexicon": "sowpods"}`)))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
+
+func TestGetMoveBudget(t *testing.T) {
+	trie := wordlist.NewTrie()
+	trie.AddWord("cab")
+	s := Server{SearchSpace: trie, WordTree: trie}
+	body := `{"moves": [], "rack": [{"letter": "c"}, {"letter": "a"}, {"letter": "b"}]}`
+
+	var play ScoredMoveJS
+	rw := httptest.NewRecorder()
+	s.GetMove(rw, httptest.NewRequest(http.MethodPost, "/play", strings.NewReader(body)))
+	assert.NoError(t, json.NewDecoder(rw.Body).Decode(&play))
+	assert.Len(t, play.Tiles, 3)
+
+	s.MoveBudget = time.Nanosecond
+	play = ScoredMoveJS{}
+	rw = httptest.NewRecorder()
+	s.GetMove(rw, httptest.NewRequest(http.MethodPost, "/play", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.NoError(t, json.NewDecoder(rw.Body).Decode(&play))
+	assert.Empty(t, play.Tiles)
+}
+
+func TestGetMoveLeaks(t *testing.T) {
+	defer leaktest.Check(t)()
+	engine := ai.NewEngine(2)
+	defer engine.Close()
+	wordDB := wordlist.MakeDefaultWordList()
+	s := Server{SearchSpace: wordDB, WordTree: wordDB, MoveBudget: time.Second, Engine: engine}
+	body := `{"moves": [], "rack": [{"letter": "r"}, {"letter": "e"}, {"letter": "t"}, {"letter": "a"}, {"letter": "i"}, {"letter": "n"}, {"letter": "s"}]}`
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rw := httptest.NewRecorder()
+			s.GetMove(rw, httptest.NewRequest(http.MethodPost, "/play", strings.NewReader(body)))
+			assert.Equal(t, http.StatusOK, rw.Code)
+			var play ScoredMoveJS
+			assert.NoError(t, json.NewDecoder(rw.Body).Decode(&play))
+			assert.NotEmpty(t, play.Tiles)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,120 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+)
+
+const (
+	defaultMoveCount = 10
+	maxMoveCount     = 100
+)
+
+// MovesRequest asks for the best moves for the rack after the given moves.
+// Count defaults to 10, and Evaluator names one of the server's Evaluators,
+// ranking by score if empty.
+type MovesRequest struct {
+	MoveRequest
+	Count     int    `json:"count,omitempty"`
+	Evaluator string `json:"evaluator,omitempty"`
+}
+
+// RankedMoveJS is a move ranked by an evaluator. Components add up to Value.
+type RankedMoveJS struct {
+	ScoredMoveJS
+	Leave      []TileJS      `json:"leave"`
+	Value      float64       `json:"value"`
+	Components []ComponentJS `json:"components"`
+}
+
+// ComponentJS is one named part of a move's value
+type ComponentJS struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// evaluator finds the named evaluator
+func (s Server) evaluator(name string) (ai.MoveEvaluator, bool) {
+	if eval, ok := s.Evaluators[name]; ok {
+		return eval, true
+	}
+	if name == "" || name == "score" {
+		return ai.ScoreEvaluator{}, true
+	}
+	return nil, false
+}
+
+// MovesEndpoint lists the best moves for the rack, best first, so a player
+// can compare a play with the alternatives. The search is limited by
+// MoveBudget like GetMove.
+func (s Server) MovesEndpoint(rw http.ResponseWriter, req *http.Request) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println(r)
+		}
+	}()
+	var moves MovesRequest
+	err := json.NewDecoder(req.Body).Decode(&moves)
+	if err != nil {
+		http.Error(rw, "JSON parsing failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if moves.Count <= 0 {
+		moves.Count = defaultMoveCount
+	}
+	if moves.Count > maxMoveCount {
+		moves.Count = maxMoveCount
+	}
+	eval, ok := s.evaluator(moves.Evaluator)
+	if !ok {
+		http.Error(rw, fmt.Sprintf("Unknown evaluator %q", moves.Evaluator), http.StatusBadRequest)
+		return
+	}
+
+	wordList, wordTree, err := s.boardLexicon(moves.Lexicon)
+	if err != nil {
+		lexiconError(rw, err)
+		return
+	}
+
+	state, _ := replay(nil, moves.MoveRequest)
+
+	ctx := req.Context()
+	if s.MoveBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.MoveBudget)
+		defer cancel()
+	}
+	rack := core.NewConsumableRack(jsTilesToTiles(moves.Rack))
+	ranked := ai.TopMoves(ctx, ai.NewAnchorAI(wordList, wordTree), eval, state.Board, rack, moves.Count)
+
+	output := make([]RankedMoveJS, len(ranked))
+	for i, move := range ranked {
+		output[i] = rankedMove2JS(move)
+	}
+	json.NewEncoder(rw).Encode(output)
+}
+
+func rankedMove2JS(move ai.RankedMove) RankedMoveJS {
+	components := make([]ComponentJS, len(move.Components))
+	for j, c := range move.Components {
+		components[j] = ComponentJS{Name: c.Name, Value: c.Value}
+	}
+	return RankedMoveJS{
+		ScoredMoveJS: ScoredMoveJS{
+			Tiles: tiles2JsTiles(move.Word),
+			Row:   move.Row,
+			Col:   move.Col,
+			Dir:   dirString(move.Direction),
+			Score: move.Score,
+		},
+		Leave:      tiles2JsTiles(move.Leave),
+		Value:      move.Value,
+		Components: components,
+	}
+}
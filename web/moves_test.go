@@ -0,0 +1,54 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/ai"
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+	"github.com/stretchr/testify/assert"
+)
+
+// preferShort values each tile kept on the rack at 10 points
+type preferShort struct{}
+
+func (preferShort) Evaluate(b *core.Board, rack core.Rack, move core.ScoredMove) float64 {
+	return float64(move.Score) + 10*float64(len(rack.Rack)-len(move.Word))
+}
+
+func TestMovesEndpoint(t *testing.T) {
+	trie := wordlist.NewTrie()
+	trie.AddWord("cab")
+	trie.AddWord("ab")
+	s := Server{SearchSpace: trie, WordTree: trie, Evaluators: map[string]ai.MoveEvaluator{"short": preferShort{}}}
+	rack := `"rack": [{"letter": "c"}, {"letter": "a"}, {"letter": "b"}]`
+
+	var moves []RankedMoveJS
+	rw := httptest.NewRecorder()
+	s.MovesEndpoint(rw, httptest.NewRequest(http.MethodPost, "/moves", strings.NewReader(`{"moves": [], `+rack+`, "count": 2}`)))
+	assert.NoError(t, json.NewDecoder(rw.Body).Decode(&moves))
+	if assert.Len(t, moves, 2) {
+		assert.Len(t, moves[0].Tiles, 3)
+		assert.Equal(t, float64(moves[0].Score), moves[0].Value)
+		assert.Equal(t, []ComponentJS{{Name: "score", Value: moves[0].Value}}, moves[0].Components)
+		assert.Empty(t, moves[0].Leave)
+		assert.True(t, moves[0].Value >= moves[1].Value)
+	}
+
+	rw = httptest.NewRecorder()
+	s.MovesEndpoint(rw, httptest.NewRequest(http.MethodPost, "/moves", strings.NewReader(`{"moves": [], `+rack+`, "count": 1, "evaluator": "short"}`)))
+	assert.NoError(t, json.NewDecoder(rw.Body).Decode(&moves))
+	if assert.Len(t, moves, 1) {
+		assert.Len(t, moves[0].Tiles, 2)
+		assert.Equal(t, []TileJS{tile2JsTile(core.Rune2Letter('c').ToTile(false))}, moves[0].Leave)
+		assert.Equal(t, []ComponentJS{{Name: "score", Value: float64(moves[0].Score)}, {Name: "adjustment", Value: 10}}, moves[0].Components)
+	}
+
+	rw = httptest.NewRecorder()
+	s.MovesEndpoint(rw, httptest.NewRequest(http.MethodPost, "/moves", strings.NewReader(`{"moves": [], `+rack+`, "evaluator": "psychic"}`)))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+	"github.com/Logiraptor/word-bot/wordlist/query"
+)
+
+// Word queries take their arguments from the URL. Every query accepts
+// lexicon, tiles (the tile set probabilities are computed with), min and
+// max word length, minprob and limit. Anagram queries also accept sub=true
+// to include words using only some of the letters.
+
+// AnagramsEndpoint finds anagrams of the letters parameter, where ? is a blank
+func (s Server) AnagramsEndpoint(rw http.ResponseWriter, req *http.Request) {
+	s.wordQuery(rw, req, func(ctx context.Context, searcher *query.Searcher, params url.Values, opts query.Options) (interface{}, error) {
+		opts.Subanagrams = params.Get("sub") == "true"
+		return searcher.Anagrams(params.Get("letters"), opts)
+	})
+}
+
+// PatternEndpoint finds words matching the pattern parameter, where ? is any
+// letter and * any run of letters
+func (s Server) PatternEndpoint(rw http.ResponseWriter, req *http.Request) {
+	s.wordQuery(rw, req, func(ctx context.Context, searcher *query.Searcher, params url.Values, opts query.Options) (interface{}, error) {
+		return searcher.Pattern(ctx, params.Get("pattern"), opts)
+	})
+}
+
+// ContainingEndpoint finds words containing the letters parameter
+func (s Server) ContainingEndpoint(rw http.ResponseWriter, req *http.Request) {
+	s.wordQuery(rw, req, func(ctx context.Context, searcher *query.Searcher, params url.Values, opts query.Options) (interface{}, error) {
+		return searcher.Containing(ctx, params.Get("letters"), opts)
+	})
+}
+
+// HooksEndpoint finds the front and back hooks of the word parameter
+func (s Server) HooksEndpoint(rw http.ResponseWriter, req *http.Request) {
+	s.wordQuery(rw, req, func(ctx context.Context, searcher *query.Searcher, params url.Values, opts query.Options) (interface{}, error) {
+		return searcher.Hooks(params.Get("word"))
+	})
+}
+
+// queryTimeout limits how long a word query may search
+const queryTimeout = 10 * time.Second
+
+type queryFunc func(ctx context.Context, searcher *query.Searcher, params url.Values, opts query.Options) (interface{}, error)
+
+func (s Server) wordQuery(rw http.ResponseWriter, req *http.Request, run queryFunc) {
+	params := req.URL.Query()
+	wordList, trie, err := s.lexicon(params.Get("lexicon"))
+	if err != nil {
+		lexiconError(rw, err)
+		return
+	}
+
+	alphabet := core.English
+	if lexicon, ok := wordList.(*wordlist.Lexicon); ok {
+		alphabet = lexicon.Alphabet
+	}
+	tiles, ok := core.TileSetFor(alphabet)
+	if name := params.Get("tiles"); name != "" {
+		tiles, ok = core.LookupTileSet(name)
+	}
+	if !ok || tiles.Alphabet != alphabet {
+		http.Error(rw, fmt.Sprintf("No %s tile set named %q", alphabet.Name, params.Get("tiles")), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := queryOptions(params)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), queryTimeout)
+	defer cancel()
+	output, err := run(ctx, query.NewSearcher(trie, tiles), params, opts)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(rw).Encode(output)
+}
+
+func queryOptions(params url.Values) (query.Options, error) {
+	var opts query.Options
+	ints := map[string]*int{"min": &opts.MinLength, "max": &opts.MaxLength, "limit": &opts.Limit}
+	for name, field := range ints {
+		if value := params.Get(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return opts, fmt.Errorf("bad %s: %s", name, err)
+			}
+			*field = n
+		}
+	}
+	if value := params.Get("minprob"); value != "" {
+		p, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return opts, fmt.Errorf("bad minprob: %s", err)
+		}
+		opts.MinProbability = p
+	}
+	return opts, nil
+}
@@ -0,0 +1,52 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+	"github.com/stretchr/testify/assert"
+)
+
+func queryServer() Server {
+	trie, _ := wordlist.LoadTrie(core.English, strings.NewReader("cat\nact\nscat\ncats\n"))
+	return Server{
+		WordTree: trie,
+		Lexicons: wordlist.NewRegistry(wordlist.WordsLexicon("kids", core.English, []byte("cat\n"))),
+	}
+}
+
+func TestAnagramsEndpoint(t *testing.T) {
+	rw := httptest.NewRecorder()
+	queryServer().AnagramsEndpoint(rw, httptest.NewRequest(http.MethodGet, "/words/anagrams?letters=tac&sub=true&minprob=0.0001", nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"word":"act"`)
+	assert.Contains(t, rw.Body.String(), `"word":"cat"`)
+
+	rw = httptest.NewRecorder()
+	queryServer().AnagramsEndpoint(rw, httptest.NewRequest(http.MethodGet, "/words/anagrams?letters=tac&lexicon=kids", nil))
+	assert.Contains(t, rw.Body.String(), `"word":"cat"`)
+	assert.NotContains(t, rw.Body.String(), `"word":"act"`)
+}
+
+func TestQueryEndpointErrors(t *testing.T) {
+	for _, url := range []string{
+		"/words/pattern?pattern=c?t&limit=many",
+		"/words/pattern?pattern=c?t&tiles=scrabble-fr",
+		"/words/pattern?pattern=c1t",
+		"/words/pattern?pattern=c?t&lexicon=sowpods",
+	} {
+		rw := httptest.NewRecorder()
+		queryServer().PatternEndpoint(rw, httptest.NewRequest(http.MethodGet, url, nil))
+		assert.Equal(t, http.StatusBadRequest, rw.Code, url)
+	}
+}
+
+func TestHooksEndpoint(t *testing.T) {
+	rw := httptest.NewRecorder()
+	queryServer().HooksEndpoint(rw, httptest.NewRequest(http.MethodGet, "/words/hooks?word=cat", nil))
+	assert.JSONEq(t, `{"front": ["s"], "back": ["s"]}`, rw.Body.String())
+}
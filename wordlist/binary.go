@@ -0,0 +1,296 @@
+package wordlist
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/bits"
+	"os"
+	"path/filepath"
+)
+
+// Prebuilt graph files start with graphMagic, then a little endian header
+// of the format version, the graph kind, the ListChecksum of the word list
+// the graph was built from and the number of nodes and edges. Nodes follow
+// in order, children before parents and the root last, as the masks of their
+// edges. Then come the edges' target nodes in the same order.
+const (
+	graphMagic   = "wordbot\x00"
+	GraphVersion = 2
+
+	trieKind   = 1
+	gaddagKind = 2
+
+	headerSize = len(graphMagic) + 5*4
+)
+
+// UnknownSource is the source checksum of graphs written without one. They
+// are never loaded in place of the embedded word list.
+const UnknownSource = 0
+
+// Default file names of prebuilt graphs, see MakeDefaultWordList
+const (
+	TrieFile   = "words.trie"
+	GaddagFile = "words.gaddag"
+)
+
+// GraphDirEnv names the environment variable holding the directory prebuilt
+// graphs are loaded from
+const GraphDirEnv = "WORD_BOT_GRAPHS"
+
+var ErrNotGraph = errors.New("not a word graph file")
+
+// ListChecksum identifies the word list a graph is built from, so a graph
+// built from an older version of the list isn't loaded in its place
+func ListChecksum(list []byte) uint32 {
+	return crc32.ChecksumIEEE(list)
+}
+
+// WriteTo writes the trie in the prebuilt graph format with an unknown
+// source, see WriteTrie
+func (t *Trie) WriteTo(w io.Writer) (int64, error) {
+	return WriteTrie(w, t, UnknownSource)
+}
+
+// WriteTo writes the gaddag in the prebuilt graph format with an unknown
+// source, see WriteGaddag
+func (g *Gaddag) WriteTo(w io.Writer) (int64, error) {
+	return WriteGaddag(w, g, UnknownSource)
+}
+
+// WriteTrie writes the trie in the prebuilt graph format, recording the
+// ListChecksum of the word list it was built from
+func WriteTrie(w io.Writer, t *Trie, source uint32) (int64, error) {
+	return writeGraph(w, trieKind, source, (*node)(t))
+}
+
+// WriteGaddag writes the gaddag like WriteTrie
+func WriteGaddag(w io.Writer, g *Gaddag, source uint32) (int64, error) {
+	return writeGraph(w, gaddagKind, source, (*node)(g))
+}
+
+// ReadTrie decodes a trie written by WriteTrie
+func ReadTrie(data []byte) (*Trie, error) {
+	root, _, err := readGraph(data, trieKind)
+	return (*Trie)(root), err
+}
+
+// ReadGaddag decodes a gaddag written by WriteGaddag
+func ReadGaddag(data []byte) (*Gaddag, error) {
+	root, _, err := readGraph(data, gaddagKind)
+	return (*Gaddag)(root), err
+}
+
+// OpenTrie reads and decodes a prebuilt trie file
+func OpenTrie(path string) (*Trie, error) {
+	root, _, err := openGraph(path, trieKind)
+	return (*Trie)(root), err
+}
+
+// OpenGaddag reads and decodes a prebuilt gaddag file
+func OpenGaddag(path string) (*Gaddag, error) {
+	root, _, err := openGraph(path, gaddagKind)
+	return (*Gaddag)(root), err
+}
+
+// StaleGraphError is returned when a prebuilt graph was built from another
+// word list than the one it would replace
+type StaleGraphError struct {
+	Path string
+}
+
+func (e *StaleGraphError) Error() string {
+	return fmt.Sprintf("%s was built from a different word list, rebuild it with build-graphs", e.Path)
+}
+
+// loadPrebuilt loads the prebuilt graph file called name if there is one
+// built from list. Stale, outdated and corrupt files are logged and ignored,
+// so the caller builds the graph from list instead.
+func loadPrebuilt(name string, kind uint32, list []byte) (*node, bool) {
+	path, ok := prebuiltGraph(name)
+	if !ok {
+		return nil, false
+	}
+	root, err := openPrebuilt(path, kind, ListChecksum(list))
+	if err != nil {
+		log.Printf("Ignoring prebuilt word list: %v", err)
+		return nil, false
+	}
+	return root, true
+}
+
+// openPrebuilt reads a prebuilt graph, checking it was built from the word
+// list with the given checksum
+func openPrebuilt(path string, kind, source uint32) (*node, error) {
+	root, fileSource, err := openGraph(path, kind)
+	if err != nil {
+		return nil, err
+	}
+	if fileSource == UnknownSource || fileSource != source {
+		return nil, &StaleGraphError{Path: path}
+	}
+	return root, nil
+}
+
+// prebuiltGraph returns the path of a prebuilt graph file, looking in the
+// directory named by GraphDirEnv or else next to the executable
+func prebuiltGraph(name string) (string, bool) {
+	dir := os.Getenv(GraphDirEnv)
+	if dir == "" {
+		executable, err := os.Executable()
+		if err != nil {
+			return "", false
+		}
+		dir = filepath.Dir(executable)
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// openGraph reads the whole file and decodes its nodes into memory
+func openGraph(path string, kind uint32) (*node, uint32, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	root, source, err := readGraph(data, kind)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %v", path, err)
+	}
+	return root, source, nil
+}
+
+func writeGraph(w io.Writer, kind, source uint32, root *node) (int64, error) {
+	// number the nodes children first, so the root comes last
+	ids := map[*node]uint32{}
+	var order []*node
+	edges := 0
+	var visit func(n *node)
+	visit = func(n *node) {
+		if _, ok := ids[n]; ok {
+			return
+		}
+		for _, c := range n.children {
+			visit(c)
+		}
+		ids[n] = uint32(len(order))
+		order = append(order, n)
+		edges += len(n.children)
+	}
+	visit(root)
+
+	buffered := bufio.NewWriter(w)
+	out := &countingWriter{w: buffered}
+	out.write([]byte(graphMagic))
+	for _, v := range []uint32{GraphVersion, kind, source, uint32(len(order)), uint32(edges)} {
+		out.uint32(v)
+	}
+	for _, n := range order {
+		out.uint64(n.mask &^ frozenBit)
+	}
+	for _, n := range order {
+		for _, c := range n.children {
+			out.uint32(ids[c])
+		}
+	}
+	if out.err == nil {
+		out.err = buffered.Flush()
+	}
+	return out.n, out.err
+}
+
+func readGraph(data []byte, kind uint32) (*node, uint32, error) {
+	if len(data) < len(graphMagic)+4 || string(data[:len(graphMagic)]) != graphMagic {
+		return nil, 0, ErrNotGraph
+	}
+	header := data[len(graphMagic):]
+	version := binary.LittleEndian.Uint32(header)
+	if version != GraphVersion {
+		return nil, 0, fmt.Errorf("word graph version %d, expected %d", version, GraphVersion)
+	}
+	if len(data) < headerSize {
+		return nil, 0, ErrNotGraph
+	}
+	fileKind := binary.LittleEndian.Uint32(header[4:])
+	source := binary.LittleEndian.Uint32(header[8:])
+	nodeCount := int(binary.LittleEndian.Uint32(header[12:]))
+	edgeCount := int(binary.LittleEndian.Uint32(header[16:]))
+	switch {
+	case fileKind != kind:
+		return nil, 0, fmt.Errorf("word graph is a %s, expected a %s", kindName(fileKind), kindName(kind))
+	case nodeCount == 0 || len(data) != headerSize+8*nodeCount+4*edgeCount:
+		return nil, 0, fmt.Errorf("word graph has the wrong size for %d nodes and %d edges", nodeCount, edgeCount)
+	}
+
+	masks := data[headerSize:]
+	targets := masks[8*nodeCount:]
+	nodes := make([]node, nodeCount)
+	edges := make([]*node, edgeCount)
+	for i := range nodes {
+		mask := binary.LittleEndian.Uint64(masks[8*i:])
+		count := bits.OnesCount64(mask & edgeMask)
+		if mask&^(edgeMask|terminalBit) != 0 || count > len(edges) {
+			return nil, 0, fmt.Errorf("word graph node %d is corrupt", i)
+		}
+		n := &nodes[i]
+		n.mask = mask | frozenBit
+		n.children = edges[:count:count]
+		edges = edges[count:]
+		for j := range n.children {
+			child := int(binary.LittleEndian.Uint32(targets))
+			targets = targets[4:]
+			if child >= i {
+				return nil, 0, fmt.Errorf("word graph node %d is corrupt", i)
+			}
+			n.children[j] = &nodes[child]
+		}
+	}
+	if len(edges) != 0 {
+		return nil, 0, fmt.Errorf("word graph has %d unused edges", len(edges))
+	}
+	return &nodes[nodeCount-1], source, nil
+}
+
+func kindName(kind uint32) string {
+	switch kind {
+	case trieKind:
+		return "trie"
+	case gaddagKind:
+		return "gaddag"
+	}
+	return fmt.Sprintf("graph of kind %d", kind)
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+	buf [8]byte
+}
+
+func (c *countingWriter) write(p []byte) {
+	if c.err != nil {
+		return
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+}
+
+func (c *countingWriter) uint32(v uint32) {
+	binary.LittleEndian.PutUint32(c.buf[:], v)
+	c.write(c.buf[:4])
+}
+
+func (c *countingWriter) uint64(v uint64) {
+	binary.LittleEndian.PutUint64(c.buf[:], v)
+	c.write(c.buf[:])
+}
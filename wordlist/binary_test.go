@@ -0,0 +1,142 @@
+package wordlist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrieRoundTrip(t *testing.T) {
+	builder := NewTrieBuilder(len(graphWords))
+	for _, w := range graphWords {
+		builder.AddWord(w)
+	}
+	trie := builder.Build()
+
+	var buf bytes.Buffer
+	n, err := trie.WriteTo(&buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(buf.Len()), n)
+
+	read, err := ReadTrie(buf.Bytes())
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, w := range []string{"cats", "bat", "at", "scat", "ca", "stabs"} {
+		assert.Equal(t, trie.Contains(core.MakeWord(w)), read.Contains(core.MakeWord(w)), w)
+	}
+	assert.Panics(t, func() { read.AddWord("dog") })
+}
+
+func TestOpenGaddag(t *testing.T) {
+	gaddag := NewGaddag()
+	for _, w := range graphWords {
+		gaddag.AddWord(w)
+	}
+
+	dir, err := ioutil.TempDir("", "graphs")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, GaddagFile)
+	f, err := os.Create(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = gaddag.WriteTo(f)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	opened, err := OpenGaddag(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	want, got := gaddag.DumpOptions(), opened.DumpOptions()
+	sort.Strings(want)
+	sort.Strings(got)
+	assert.Equal(t, want, got)
+
+	_, err = OpenTrie(path)
+	assert.EqualError(t, err, path+": word graph is a gaddag, expected a trie")
+}
+
+func TestReadGraphErrors(t *testing.T) {
+	var buf bytes.Buffer
+	NewTrieBuilder(0).Build().WriteTo(&buf)
+	data := buf.Bytes()
+
+	_, err := ReadTrie([]byte("words\n"))
+	assert.Equal(t, ErrNotGraph, err)
+
+	_, err = ReadTrie(data[:len(data)-1])
+	assert.Error(t, err)
+
+	future := append([]byte(nil), data...)
+	future[len(graphMagic)] = GraphVersion + 1
+	_, err = ReadTrie(future)
+	assert.EqualError(t, err, "word graph version 3, expected 2")
+}
+
+func TestMakeDefaultWordListLoadsPrebuiltGraph(t *testing.T) {
+	dir, err := ioutil.TempDir("", "graphs")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	builder := NewTrieBuilder(1)
+	builder.AddWord("zzz")
+	trie := builder.Build()
+	var buf bytes.Buffer
+	WriteTrie(&buf, trie, ListChecksum(MustAsset("words.txt")))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, TrieFile), buf.Bytes(), 0644))
+
+	defer os.Setenv(GraphDirEnv, os.Getenv(GraphDirEnv))
+	os.Setenv(GraphDirEnv, dir)
+	loaded := MakeDefaultWordList()
+	assert.True(t, loaded.Contains(core.MakeWord("zzz")))
+	assert.False(t, loaded.Contains(core.MakeWord("foot")))
+
+	// a graph of another list is ignored
+	buf.Reset()
+	WriteTrie(&buf, trie, ListChecksum([]byte("zzz\n")))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, TrieFile), buf.Bytes(), 0644))
+	loaded = MakeDefaultWordList()
+	assert.False(t, loaded.Contains(core.MakeWord("zzz")))
+	assert.True(t, loaded.Contains(core.MakeWord("foot")))
+}
+
+func TestMakeDefaultWordListIgnoresBrokenGraphs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "graphs")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv(GraphDirEnv, os.Getenv(GraphDirEnv))
+	os.Setenv(GraphDirEnv, dir)
+
+	var buf bytes.Buffer
+	WriteTrie(&buf, NewTrieBuilder(0).Build(), ListChecksum(MustAsset("words.txt")))
+	outdated := append([]byte(nil), buf.Bytes()...)
+	binary.LittleEndian.PutUint32(outdated[len(graphMagic):], 1)
+
+	for name, data := range map[string][]byte{
+		"outdated":  outdated,
+		"truncated": buf.Bytes()[:headerSize-1],
+		"corrupt":   []byte("not a graph"),
+	} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, TrieFile), data, 0644))
+		loaded := MakeDefaultWordList()
+		assert.True(t, loaded.Contains(core.MakeWord("foot")), "the embedded words weren't loaded in place of a %s graph", name)
+	}
+}
@@ -0,0 +1,108 @@
+// Command build-graphs writes the trie and gaddag of a word list in the
+// prebuilt graph format, so programs can load them instead of building
+// them at startup. Put the files next to a binary, or point WORD_BOT_GRAPHS
+// at their directory. Graphs record a checksum of the word list, and are
+// only loaded in place of that same list. The graphs are checked against
+// each other with wordlist.Verify before they are written; -check verifies
+// the files already in -out instead.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+)
+
+func main() {
+	words := flag.String("words", "", "newline separated word list (default: the embedded word list)")
+	alphabetName := flag.String("alphabet", core.English.Name, "alphabet the word list is spelled in")
+	out := flag.String("out", ".", "directory to write "+wordlist.TrieFile+" and "+wordlist.GaddagFile+" to")
+	check := flag.Bool("check", false, "verify the graphs in -out rather than building them")
+	flag.Parse()
+
+	alphabet, ok := core.LookupAlphabet(*alphabetName)
+	if !ok {
+		log.Fatalf("unknown alphabet %q", *alphabetName)
+	}
+
+	if *check {
+		trie, err := wordlist.OpenTrie(filepath.Join(*out, wordlist.TrieFile))
+		if err != nil {
+			log.Fatal(err)
+		}
+		gaddag, err := wordlist.OpenGaddag(filepath.Join(*out, wordlist.GaddagFile))
+		if err != nil {
+			log.Fatal(err)
+		}
+		verify(alphabet, trie, gaddag)
+		return
+	}
+
+	list := wordlist.MustAsset("words.txt")
+	if *words != "" {
+		var err error
+		list, err = ioutil.ReadFile(*words)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	start := time.Now()
+	trie, err := wordlist.LoadTrie(alphabet, bytes.NewReader(list))
+	if err != nil {
+		log.Fatal(err)
+	}
+	trieTime := time.Since(start)
+
+	start = time.Now()
+	gaddag, err := wordlist.LoadGaddag(alphabet, bytes.NewReader(list))
+	if err != nil {
+		log.Fatal(err)
+	}
+	gaddagTime := time.Since(start)
+
+	verify(alphabet, trie, gaddag)
+	source := wordlist.ListChecksum(list)
+	write(filepath.Join(*out, wordlist.TrieFile), func(w io.Writer) (int64, error) {
+		return wordlist.WriteTrie(w, trie, source)
+	}, trieTime)
+	write(filepath.Join(*out, wordlist.GaddagFile), func(w io.Writer) (int64, error) {
+		return wordlist.WriteGaddag(w, gaddag, source)
+	}, gaddagTime)
+}
+
+// verify exits if the gaddag and trie disagree on any word
+func verify(alphabet *core.Alphabet, trie *wordlist.Trie, gaddag *wordlist.Gaddag) {
+	found := wordlist.Verify(trie, gaddag)
+	for _, d := range found {
+		fmt.Printf("%s: %s split at %d\n", d.Kind, alphabet.WordString(d.Word), d.Split)
+	}
+	if len(found) > 0 {
+		log.Fatalf("%d discrepancies between the trie and gaddag", len(found))
+	}
+	fmt.Println("trie and gaddag agree")
+}
+
+func write(path string, writeGraph func(w io.Writer) (int64, error), built time.Duration) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	n, err := writeGraph(f)
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("built %s (%d bytes) in %s\n", path, n, built)
+}
@@ -0,0 +1,53 @@
+package wordlist
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Diff lists the words added and removed between two word lists, in order
+type Diff struct {
+	Added   []string
+	Removed []string
+}
+
+// ReadWords reads a newline separated word list, returning its distinct
+// lowercase words in order
+func ReadWords(r io.Reader) ([]string, error) {
+	seen := map[string]bool{}
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(words)
+	return words, nil
+}
+
+// DiffWords compares two sorted word lists, as returned by ReadWords
+func DiffWords(old, new []string) Diff {
+	var d Diff
+	for len(old) > 0 || len(new) > 0 {
+		switch {
+		case len(new) == 0 || len(old) > 0 && old[0] < new[0]:
+			d.Removed = append(d.Removed, old[0])
+			old = old[1:]
+		case len(old) == 0 || new[0] < old[0]:
+			d.Added = append(d.Added, new[0])
+			new = new[1:]
+		default:
+			old, new = old[1:], new[1:]
+		}
+	}
+	return d
+}
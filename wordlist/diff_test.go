@@ -0,0 +1,22 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadWords(t *testing.T) {
+	words, err := ReadWords(strings.NewReader("cab\r\nAA\n\nzo\naa\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aa", "cab", "zo"}, words)
+}
+
+func TestDiffWords(t *testing.T) {
+	d := DiffWords([]string{"aa", "cab", "qi", "zo"}, []string{"aa", "ok", "qi", "za", "zzz"})
+	assert.Equal(t, []string{"ok", "za", "zzz"}, d.Added)
+	assert.Equal(t, []string{"cab", "zo"}, d.Removed)
+
+	assert.Equal(t, Diff{}, DiffWords([]string{"aa"}, []string{"aa"}))
+}
@@ -5,68 +5,76 @@ import (
 	"io"
 
 	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/definitions"
 )
 
-const reverseToken = ('z' - 'a') + 1
+// reverseToken follows every letter of the alphabet, so it is the same for all alphabets
+const reverseToken = core.MaxLetters
 
-type Gaddag struct {
-	nodes    [27]*Gaddag
-	terminal bool
-}
+// Gaddag is a word graph which reads each word from any letter forward to
+// its end, then across reverseToken backwards from the letter before the
+// split to its start. Gaddags built by a GaddagBuilder or the loaders are
+// minimized and can no longer be added to.
+type Gaddag node
 
 func NewGaddag() *Gaddag {
 	return &Gaddag{}
 }
 
-func (g *Gaddag) AddWord(word string) {
-	contents := []rune(word)
-	for i := range contents {
-		g.insertLinearString(contents[:i], contents[i:])
+// LoadGaddag builds a Gaddag from a newline separated word list, splitting
+// each word into letters with the given alphabet. Words that cannot be
+// spelled with the alphabet are skipped.
+func LoadGaddag(alphabet *core.Alphabet, r io.Reader) (*Gaddag, error) {
+	builder := NewGaddagBuilder()
+	err := definitions.LoadDefinitionsReader(r, alphabetWordDB{alphabet, builder})
+	if err != nil {
+		return nil, err
 	}
+	return builder.Build(), nil
 }
 
-func (g *Gaddag) insertLinearString(start, end []rune) {
-	current := g
-	for _, r := range end {
-		if current.nodes[r-'a'] == nil {
-			current.nodes[r-'a'] = NewGaddag()
-		}
-		current = current.nodes[r-'a']
-	}
+// AddWord adds a word spelled in the English alphabet
+func (g *Gaddag) AddWord(word string) {
+	g.AddLetters(core.MakeWord(word))
+}
 
-	if current.nodes[reverseToken] == nil {
-		current.nodes[reverseToken] = NewGaddag()
+// AddLetters adds a word which has already been split into letters
+func (g *Gaddag) AddLetters(word core.Word) {
+	for i := range word {
+		g.insertLinearString(word[:i], word[i:])
 	}
-	current = current.nodes[reverseToken]
+}
 
+func (g *Gaddag) insertLinearString(start, end core.Word) {
+	current := (*node)(g)
+	for _, r := range end {
+		current = current.add(r)
+	}
+	current = current.add(reverseToken)
 	for i := len(start) - 1; i >= 0; i-- {
-		r := start[i]
-		if current.nodes[r-'a'] == nil {
-			current.nodes[r-'a'] = NewGaddag()
-		}
-		current = current.nodes[r-'a']
+		current = current.add(start[i])
 	}
-	current.terminal = true
+	current.mask |= terminalBit
 }
 
 func (g *Gaddag) CanBranch(l core.Tile) bool {
-	return g.nodes[l.ToLetter()] != nil
+	return (*node)(g).child(l.ToLetter()) != nil
 }
 
 func (g *Gaddag) Branch(l core.Tile) *Gaddag {
-	return g.nodes[l.ToLetter()]
+	return (*Gaddag)((*node)(g).child(l.ToLetter()))
 }
 
 func (g *Gaddag) CanReverse() bool {
-	return g.nodes[reverseToken] != nil
+	return (*node)(g).child(reverseToken) != nil
 }
 
 func (g *Gaddag) Reverse() *Gaddag {
-	return g.nodes[reverseToken]
+	return (*Gaddag)((*node)(g).child(reverseToken))
 }
 
 func (g *Gaddag) IsTerminal() bool {
-	return g.terminal
+	return (*node)(g).isTerminal()
 }
 
 func (g *Gaddag) DumpOptions() []string {
@@ -74,35 +82,34 @@ func (g *Gaddag) DumpOptions() []string {
 	if g.IsTerminal() {
 		output = append(output, ".")
 	}
-	for i, n := range g.nodes {
-		if n == nil {
-			continue
-		}
-		r := string(core.Letter(i).ToRune())
-		if i == reverseToken {
+	(*node)(g).each(func(letter core.Letter, n *node) {
+		r := string(letter.ToRune())
+		if letter == reverseToken {
 			r = "#"
 		}
-		subStrings := n.DumpOptions()
-		for _, s := range subStrings {
+		for _, s := range (*Gaddag)(n).DumpOptions() {
 			output = append(output, r+s)
 		}
-	}
+	})
 	return output
 }
 
 func (g *Gaddag) DumpToDot(wr io.Writer) {
 	fmt.Fprintln(wr, "digraph {")
-	g.dumpToDot(wr)
+	g.dumpToDot(wr, map[*Gaddag]bool{})
 	fmt.Fprintln(wr, "}")
 }
 
-func (g *Gaddag) dumpToDot(wr io.Writer) {
-	for i, n := range g.nodes {
-		if n == nil {
-			continue
-		}
-		r := string(core.Letter(i).ToRune())
-		if i == reverseToken {
+// dumpToDot writes each shared node once
+func (g *Gaddag) dumpToDot(wr io.Writer, seen map[*Gaddag]bool) {
+	if seen[g] {
+		return
+	}
+	seen[g] = true
+	(*node)(g).each(func(letter core.Letter, child *node) {
+		n := (*Gaddag)(child)
+		r := string(letter.ToRune())
+		if letter == reverseToken {
 			r = "#"
 		}
 
@@ -115,6 +122,41 @@ func (g *Gaddag) dumpToDot(wr io.Writer) {
 
 		fmt.Fprintf(wr, "\"%p\" -> \"%p\" [label=\"%s\"];\n", g, n, r)
 		fmt.Fprintf(wr, "\"%p\" [label=\"%s\" color=\"%s\"];\n", n, "__", color)
-		n.dumpToDot(wr)
+		n.dumpToDot(wr, seen)
+	})
+}
+
+// GaddagBuilder collects words and builds a minimized Gaddag from them
+type GaddagBuilder struct {
+	graph   graphBuilder
+	letters []byte
+}
+
+func NewGaddagBuilder() *GaddagBuilder {
+	return &GaddagBuilder{}
+}
+
+// AddWord adds a word spelled in the English alphabet
+func (g *GaddagBuilder) AddWord(word string) {
+	g.AddLetters(core.MakeWord(word))
+}
+
+// AddLetters adds a word which has already been split into letters
+func (g *GaddagBuilder) AddLetters(word core.Word) {
+	for i := range word {
+		g.letters = g.letters[:0]
+		for _, letter := range word[i:] {
+			g.letters = append(g.letters, byte(letter))
+		}
+		g.letters = append(g.letters, reverseToken)
+		for j := i - 1; j >= 0; j-- {
+			g.letters = append(g.letters, byte(word[j]))
+		}
+		g.graph.add(g.letters)
 	}
 }
+
+// Build returns the minimized Gaddag of every word added so far and resets the builder
+func (g *GaddagBuilder) Build() *Gaddag {
+	return (*Gaddag)(g.graph.build())
+}
@@ -1,6 +1,7 @@
 package wordlist
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Logiraptor/word-bot/core"
@@ -78,3 +79,31 @@ func TestGaddagTerminal(t *testing.T) {
 	assert.False(t, t3.IsTerminal())
 	assert.True(t, t4.IsTerminal())
 }
+
+func TestSpanishWordGraphs(t *testing.T) {
+	words := "chorro\nniño\nkiwi\n"
+	trie, err := LoadTrie(core.Spanish, strings.NewReader(words))
+	if !assert.NoError(t, err) {
+		return
+	}
+	gaddag, err := LoadGaddag(core.Spanish, strings.NewReader(words))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, trie.Contains(core.Spanish.MustParse("chorro")))
+	assert.True(t, trie.Contains(core.Spanish.MustParse("niño")))
+	assert.False(t, trie.Contains(core.Spanish.MustParse("nino")))
+
+	// niño ends in ñ-o, so the gaddag can start from o, reverse, then read ñ
+	n := core.Spanish.MustParse("ñ")[0].ToTile(false)
+	o := core.Spanish.MustParse("o")[0].ToTile(false)
+	assert.True(t, gaddag.Branch(o).Reverse().CanBranch(n))
+	assert.True(t, gaddag.Branch(n).CanBranch(o))
+}
+
+func BenchmarkLoadGaddag(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		MakeDefaultWordListGaddag()
+	}
+}
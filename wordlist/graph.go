@@ -0,0 +1,275 @@
+package wordlist
+
+import (
+	"bytes"
+	"math/bits"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// Bits of a node's mask above its letter edges
+const (
+	edgeMask    = 1<<(reverseToken+1) - 1
+	frozenBit   = 1 << 62
+	terminalBit = 1 << 63
+)
+
+// node is a state in a Trie or Gaddag. Bit i of mask is set when the node
+// has an edge for letter i, and children holds the targets of those edges in
+// letter order, so a node costs 32 bytes plus 8 per edge. Nodes of a built
+// graph are frozen: they may be shared between many parents, so adding
+// words to them would corrupt other words.
+type node struct {
+	mask     uint64
+	children []*node
+}
+
+func (n *node) child(letter core.Letter) *node {
+	bit := uint64(1) << uint(letter) & edgeMask
+	if n.mask&bit == 0 {
+		return nil
+	}
+	return n.children[bits.OnesCount64(n.mask&(bit-1))]
+}
+
+func (n *node) isTerminal() bool {
+	return n.mask&terminalBit != 0
+}
+
+// add returns the child for letter, creating it if needed
+func (n *node) add(letter core.Letter) *node {
+	if n.mask&frozenBit != 0 {
+		panic("wordlist: cannot add words to a built word graph")
+	}
+	bit := uint64(1) << uint(letter)
+	i := bits.OnesCount64(n.mask & (bit - 1))
+	if n.mask&bit != 0 {
+		return n.children[i]
+	}
+	n.mask |= bit
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = &node{}
+	return n.children[i]
+}
+
+// each calls f for every edge out of n in letter order
+func (n *node) each(f func(core.Letter, *node)) {
+	edges := n.mask & edgeMask
+	for i := 0; edges != 0; i++ {
+		letter := bits.TrailingZeros64(edges)
+		f(core.Letter(letter), n.children[i])
+		edges &= edges - 1
+	}
+}
+
+// graphBuilder collects strings of letters and builds the minimal graph
+// accepting them, in which every pair of equivalent states is shared. It
+// uses the sorted input algorithm from Daciuk et al., "Incremental
+// Construction of Minimal Acyclic Finite-State Automata".
+type graphBuilder struct {
+	// text holds every queued string back to back
+	text  []byte
+	words []span
+
+	// path[i] is the state reached by the first i letters of prev
+	path []pendingState
+	prev []byte
+
+	// registered states, children before parents; the children of state i
+	// are edges[firsts[i]:]
+	masks  []uint64
+	firsts []int32
+	edges  []int32
+	// register is an open addressing hash table of registered state ids
+	// plus one, so that zero marks an empty slot
+	register []int32
+}
+
+type span struct {
+	start, end int32
+}
+
+// pendingState is a state on the path of the last string inserted, which
+// may still gain edges
+type pendingState struct {
+	mask     uint64
+	children []int32
+}
+
+// add queues a string of letters, each of which must be at most reverseToken
+func (g *graphBuilder) add(word []byte) {
+	start := int32(len(g.text))
+	g.text = append(g.text, word...)
+	g.words = append(g.words, span{start, int32(len(g.text))})
+}
+
+func (g *graphBuilder) build() *node {
+	g.sort(g.words, make([]span, len(g.words)), 0)
+	g.register = make([]int32, 1<<10)
+	g.path = append(g.path[:0], pendingState{})
+	for _, w := range g.words {
+		g.insert(g.text[w.start:w.end])
+	}
+	g.minimize(0)
+	root := g.registerState(&g.path[0])
+	return g.pack(root)
+}
+
+// insert adds a string which sorts after every string inserted so far
+func (g *graphBuilder) insert(word []byte) {
+	common := 0
+	for common < len(word) && common < len(g.prev) && word[common] == g.prev[common] {
+		common++
+	}
+	if common == len(word) && common == len(g.prev) {
+		return
+	}
+	g.minimize(common)
+
+	for i := common; i < len(word); i++ {
+		g.path[i].mask |= 1 << word[i]
+		if len(g.path) < cap(g.path) {
+			g.path = g.path[:len(g.path)+1]
+		} else {
+			g.path = append(g.path, pendingState{})
+		}
+		next := &g.path[len(g.path)-1]
+		next.mask = 0
+		next.children = next.children[:0]
+	}
+	g.path[len(word)].mask |= terminalBit
+	g.prev = word
+}
+
+// minimize registers the states on the path below depth. Those states can
+// no longer change since later strings sort after them.
+func (g *graphBuilder) minimize(depth int) {
+	for i := len(g.path) - 1; i > depth; i-- {
+		id := g.registerState(&g.path[i])
+		g.path[i-1].children = append(g.path[i-1].children, id)
+	}
+	g.path = g.path[:depth+1]
+}
+
+// registerState returns the id of the registered state equivalent to s,
+// registering s if there is none
+func (g *graphBuilder) registerState(s *pendingState) int32 {
+	slot := g.find(s.mask, s.children)
+	if id := g.register[slot]; id != 0 {
+		return id - 1
+	}
+
+	id := int32(len(g.masks))
+	g.masks = append(g.masks, s.mask)
+	g.firsts = append(g.firsts, int32(len(g.edges)))
+	g.edges = append(g.edges, s.children...)
+	g.register[slot] = id + 1
+	if len(g.masks) > len(g.register)/2 {
+		g.grow()
+	}
+	return id
+}
+
+// find returns the slot of the registered state with the given mask and
+// children, or the empty slot where it belongs
+func (g *graphBuilder) find(mask uint64, children []int32) int {
+	hash := mask * 0x9E3779B97F4A7C15
+	for _, c := range children {
+		hash = (hash ^ uint64(c)) * 0x9E3779B97F4A7C15
+	}
+	slots := len(g.register) - 1
+	slot := int(hash>>32) & slots
+	for {
+		id := g.register[slot] - 1
+		if id < 0 || g.masks[id] == mask && equalIDs(g.edges[g.firsts[id]:], children) {
+			return slot
+		}
+		slot = (slot + 1) & slots
+	}
+}
+
+// equalIDs returns true if registered begins with children
+func equalIDs(registered, children []int32) bool {
+	for i, c := range children {
+		if registered[i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *graphBuilder) grow() {
+	g.register = make([]int32, 2*len(g.register))
+	for id, mask := range g.masks {
+		first := g.firsts[id]
+		count := int32(bits.OnesCount64(mask & edgeMask))
+		g.register[g.find(mask, g.edges[first:first+count])] = int32(id) + 1
+	}
+}
+
+// sort orders words by their letters from depth onwards with a most
+// significant digit radix sort, using scratch as temporary space
+func (g *graphBuilder) sort(words, scratch []span, depth int32) {
+	if len(words) < 16 {
+		for i := 1; i < len(words); i++ {
+			for j := i; j > 0 && g.less(words[j], words[j-1], depth); j-- {
+				words[j], words[j-1] = words[j-1], words[j]
+			}
+		}
+		return
+	}
+
+	// bucket 0 holds strings which end at depth
+	var counts [reverseToken + 2]int
+	for _, w := range words {
+		counts[g.digit(w, depth)]++
+	}
+	var starts [reverseToken + 2]int
+	for i := 1; i < len(counts); i++ {
+		starts[i] = starts[i-1] + counts[i-1]
+	}
+	next := starts
+	for _, w := range words {
+		d := g.digit(w, depth)
+		scratch[next[d]] = w
+		next[d]++
+	}
+	copy(words, scratch)
+
+	for i := 1; i < len(counts); i++ {
+		if counts[i] > 1 {
+			end := starts[i] + counts[i]
+			g.sort(words[starts[i]:end], scratch[starts[i]:end], depth+1)
+		}
+	}
+}
+
+func (g *graphBuilder) digit(w span, depth int32) int {
+	if w.start+depth >= w.end {
+		return 0
+	}
+	return int(g.text[w.start+depth]) + 1
+}
+
+func (g *graphBuilder) less(a, b span, depth int32) bool {
+	return bytes.Compare(g.text[a.start+depth:a.end], g.text[b.start+depth:b.end]) < 0
+}
+
+// pack converts the registered states into frozen nodes, returning the node for root
+func (g *graphBuilder) pack(root int32) *node {
+	nodes := make([]node, len(g.masks))
+	edges := make([]*node, len(g.edges))
+	for i, mask := range g.masks {
+		first := int(g.firsts[i])
+		count := bits.OnesCount64(mask & edgeMask)
+		n := &nodes[i]
+		n.mask = mask | frozenBit
+		n.children = edges[first : first+count : first+count]
+		for j, c := range g.edges[first : first+count] {
+			n.children[j] = &nodes[c]
+		}
+	}
+	*g = graphBuilder{}
+	return &nodes[root]
+}
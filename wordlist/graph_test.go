@@ -0,0 +1,62 @@
+package wordlist
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/stretchr/testify/assert"
+)
+
+var graphWords = []string{"cats", "bats", "cat", "bat", "at", "scat", "tabs", "stab", "bat"}
+
+func TestTrieBuilderMatchesTrie(t *testing.T) {
+	builder := NewTrieBuilder(len(graphWords))
+	tree := NewTrie()
+	for _, w := range graphWords {
+		builder.AddWord(w)
+		tree.AddWord(w)
+	}
+	built := builder.Build()
+
+	for _, w := range []string{"cats", "bat", "at", "scat", "ca", "a", "bats", "tab", "stabs", ""} {
+		assert.Equal(t, tree.Contains(core.MakeWord(w)), built.Contains(core.MakeWord(w)), w)
+	}
+}
+
+func TestTrieBuilderSharesSuffixes(t *testing.T) {
+	builder := NewTrieBuilder(len(graphWords))
+	for _, w := range graphWords {
+		builder.AddWord(w)
+	}
+	trie := builder.Build()
+
+	c, _ := trie.CanBranch(core.Rune2Letter('c').ToTile(false))
+	b, _ := trie.CanBranch(core.Rune2Letter('b').ToTile(false))
+	assert.True(t, c == b, "cat(s) and bat(s) share their suffixes")
+
+	assert.Panics(t, func() { trie.AddWord("dog") })
+}
+
+func TestGaddagBuilderMatchesGaddag(t *testing.T) {
+	builder := NewGaddagBuilder()
+	tree := NewGaddag()
+	for _, w := range graphWords {
+		builder.AddWord(w)
+		tree.AddWord(w)
+	}
+	built := builder.Build()
+
+	want, got := tree.DumpOptions(), built.DumpOptions()
+	sort.Strings(want)
+	sort.Strings(got)
+	assert.Equal(t, want, got)
+	assert.Panics(t, func() { built.AddWord("dog") })
+}
+
+func TestEmptyGraph(t *testing.T) {
+	trie := NewTrieBuilder(0).Build()
+	assert.False(t, trie.Contains(core.MakeWord("a")))
+	assert.False(t, trie.IsTerminal())
+	assert.False(t, NewGaddagBuilder().Build().CanReverse())
+}
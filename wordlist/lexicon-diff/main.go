@@ -0,0 +1,147 @@
+// Command lexicon-diff compares two word lists, printing the words added
+// and removed. With -db it reports saved games played with -lexicon whose
+// plays form words the new list doesn't contain, and with -update it replaces the embedded word
+// list with the new one.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/persist"
+	"github.com/Logiraptor/word-bot/wordlist"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func main() {
+	oldPath := flag.String("old", "", "newline separated word list to compare against (default: the embedded word list)")
+	newPath := flag.String("new", "", "newline separated word list to compare")
+	dbPath := flag.String("db", "", "sqlite database of saved games to check against the new list")
+	lexicon := flag.String("lexicon", wordlist.Default.Name, "lexicon of the saved games to check, games saved without one count as "+wordlist.Default.Name)
+	update := flag.String("update", "", "wordlist package directory to write the new list's words.txt and words.go to")
+	quiet := flag.Bool("q", false, "only print the number of words added and removed")
+	flag.Parse()
+
+	if *newPath == "" {
+		log.Fatal("-new is required")
+	}
+	oldList := wordlist.MustAsset("words.txt")
+	if *oldPath != "" {
+		oldList = readFile(*oldPath)
+	}
+	oldWords, err := wordlist.ReadWords(bytes.NewReader(oldList))
+	if err != nil {
+		log.Fatal(err)
+	}
+	newWords, err := wordlist.ReadWords(bytes.NewReader(readFile(*newPath)))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	diff := wordlist.DiffWords(oldWords, newWords)
+	if !*quiet {
+		for _, w := range diff.Added {
+			fmt.Println("+" + w)
+		}
+		for _, w := range diff.Removed {
+			fmt.Println("-" + w)
+		}
+	}
+	fmt.Printf("%d words added, %d removed\n", len(diff.Added), len(diff.Removed))
+
+	if *dbPath != "" {
+		checkGames(*dbPath, *lexicon, newWords)
+	}
+	if *update != "" {
+		if err := updateAsset(*update, newWords); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("updated the embedded word list in %s\n", *update)
+	}
+}
+
+func readFile(path string) []byte {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return data
+}
+
+func checkGames(path, lexicon string, words []string) {
+	db, err := persist.NewDB(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.DB.Close()
+
+	trie, err := wordlist.LoadTrie(core.English, strings.NewReader(strings.Join(words, "\n")))
+	if err != nil {
+		log.Fatal(err)
+	}
+	lexicons := []string{lexicon}
+	if lexicon == wordlist.Default.Name {
+		lexicons = append(lexicons, "")
+	}
+	invalid, err := db.InvalidPlays(core.ScrabbleLayout, trie, lexicons...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	games := map[uint]bool{}
+	for _, play := range invalid {
+		games[play.GameID] = true
+		fmt.Printf("game %d move %d: %s played %v forming %s\n", play.GameID, play.MoveID, play.Player, play.Move, strings.Join(play.Words, ", "))
+	}
+	fmt.Printf("%d plays in %d games form invalid words\n", len(invalid), len(games))
+}
+
+var (
+	assetData = regexp.MustCompile(`var _wordsTxt = \[\]byte\("[^"]*"\)`)
+	assetInfo = regexp.MustCompile(`info := bindataFileInfo\{name: "words.txt", [^}]*\}`)
+)
+
+// updateAsset writes words.txt and rewrites its go-bindata output in place,
+// as go generate would, so updating the list doesn't need go-bindata
+func updateAsset(dir string, words []string) error {
+	list := []byte(strings.Join(words, "\n"))
+	if err := ioutil.WriteFile(filepath.Join(dir, "words.txt"), list, 0644); err != nil {
+		return err
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(list); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	var data strings.Builder
+	data.WriteString(`var _wordsTxt = []byte("`)
+	for _, b := range compressed.Bytes() {
+		fmt.Fprintf(&data, `\x%02x`, b)
+	}
+	data.WriteString(`")`)
+	info := fmt.Sprintf(`info := bindataFileInfo{name: "words.txt", size: %d, mode: os.FileMode(420), modTime: time.Unix(%d, 0)}`, len(list), time.Now().Unix())
+
+	goFile := filepath.Join(dir, "words.go")
+	src, err := ioutil.ReadFile(goFile)
+	if err != nil {
+		return err
+	}
+	if len(assetData.FindAllIndex(src, -1)) != 1 || len(assetInfo.FindAllIndex(src, -1)) != 1 {
+		return fmt.Errorf("%s doesn't look like go-bindata output for words.txt", goFile)
+	}
+	src = assetData.ReplaceAllLiteral(src, []byte(data.String()))
+	src = assetInfo.ReplaceAllLiteral(src, []byte(info))
+	return ioutil.WriteFile(goFile, src, 0644)
+}
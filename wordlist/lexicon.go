@@ -0,0 +1,214 @@
+package wordlist
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// LexiconDirEnv names the environment variable holding a directory of extra
+// word lists, see LoadRegistry
+const LexiconDirEnv = "WORD_BOT_LEXICONS"
+
+// Default is the lexicon of the embedded word list
+var Default = &Lexicon{
+	Name:       "default",
+	Alphabet:   core.English,
+	loadTrie:   func() (*Trie, error) { return MakeDefaultWordList(), nil },
+	loadGaddag: func() (*Gaddag, error) { return MakeDefaultWordListGaddag(), nil },
+}
+
+// Lexicon is a named word list. Its graphs are built the first time they
+// are needed, so registering many lexicons is cheap.
+type Lexicon struct {
+	Name     string
+	Alphabet *core.Alphabet
+
+	loadTrie   func() (*Trie, error)
+	loadGaddag func() (*Gaddag, error)
+
+	trieOnce   sync.Once
+	trie       *Trie
+	trieErr    error
+	gaddagOnce sync.Once
+	gaddag     *Gaddag
+	gaddagErr  error
+}
+
+// NewLexicon returns a lexicon read from the newline separated word list
+// returned by open, which is called each time a graph is built
+func NewLexicon(name string, alphabet *core.Alphabet, open func() (io.ReadCloser, error)) *Lexicon {
+	return &Lexicon{
+		Name:     name,
+		Alphabet: alphabet,
+		loadTrie: func() (*Trie, error) {
+			r, err := open()
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+			return LoadTrie(alphabet, r)
+		},
+		loadGaddag: func() (*Gaddag, error) {
+			r, err := open()
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+			return LoadGaddag(alphabet, r)
+		},
+	}
+}
+
+// FileLexicon returns a lexicon read from a word list file
+func FileLexicon(name string, alphabet *core.Alphabet, path string) *Lexicon {
+	return NewLexicon(name, alphabet, func() (io.ReadCloser, error) {
+		return os.Open(path)
+	})
+}
+
+// WordsLexicon returns a lexicon of a newline separated word list held in memory
+func WordsLexicon(name string, alphabet *core.Alphabet, words []byte) *Lexicon {
+	return NewLexicon(name, alphabet, func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(words)), nil
+	})
+}
+
+// Trie returns the lexicon's trie, building it on first use
+func (l *Lexicon) Trie() (*Trie, error) {
+	l.trieOnce.Do(func() {
+		l.trie, l.trieErr = l.loadTrie()
+		if l.trieErr != nil {
+			l.trieErr = fmt.Errorf("lexicon %s: %v", l.Name, l.trieErr)
+		}
+	})
+	return l.trie, l.trieErr
+}
+
+// Gaddag returns the lexicon's gaddag, building it on first use
+func (l *Lexicon) Gaddag() (*Gaddag, error) {
+	l.gaddagOnce.Do(func() {
+		l.gaddag, l.gaddagErr = l.loadGaddag()
+		if l.gaddagErr != nil {
+			l.gaddagErr = fmt.Errorf("lexicon %s: %v", l.Name, l.gaddagErr)
+		}
+	})
+	return l.gaddag, l.gaddagErr
+}
+
+// Contains returns true if the word is in the lexicon. A lexicon which
+// failed to load contains no words.
+func (l *Lexicon) Contains(word core.Word) bool {
+	trie, err := l.Trie()
+	return err == nil && trie.Contains(word)
+}
+
+// UnknownLexiconError is returned when looking up a lexicon which isn't registered
+type UnknownLexiconError struct {
+	Name string
+}
+
+func (e *UnknownLexiconError) Error() string {
+	return fmt.Sprintf("unknown lexicon %q", e.Name)
+}
+
+// LexiconExistsError is returned when adding a lexicon under a name which is
+// already registered
+type LexiconExistsError struct {
+	Name string
+}
+
+func (e *LexiconExistsError) Error() string {
+	return fmt.Sprintf("lexicon %q already exists", e.Name)
+}
+
+// Registry holds lexicons by name. It is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	lexicons map[string]*Lexicon
+}
+
+// NewRegistry returns a registry holding the given lexicons
+func NewRegistry(lexicons ...*Lexicon) *Registry {
+	r := &Registry{lexicons: map[string]*Lexicon{}}
+	for _, l := range lexicons {
+		r.Register(l)
+	}
+	return r
+}
+
+// LoadRegistry returns a registry holding Default and, if LexiconDirEnv is
+// set, every English word list in that directory named after its file
+// without the .txt extension.
+func LoadRegistry() (*Registry, error) {
+	r := NewRegistry(Default)
+	if dir := os.Getenv(LexiconDirEnv); dir != "" {
+		if err := r.RegisterDir(dir, core.English); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a lexicon, replacing any lexicon with the same name
+func (r *Registry) Register(l *Lexicon) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lexicons[l.Name] = l
+}
+
+// Add adds a lexicon unless one with the same name is registered, in which
+// case it returns a *LexiconExistsError
+func (r *Registry) Add(l *Lexicon) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.lexicons[l.Name]; ok {
+		return &LexiconExistsError{Name: l.Name}
+	}
+	r.lexicons[l.Name] = l
+	return nil
+}
+
+// RegisterDir registers a FileLexicon for each .txt file in dir
+func (r *Registry) RegisterDir(dir string, alphabet *core.Alphabet) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		r.Register(FileLexicon(name, alphabet, path))
+	}
+	return nil
+}
+
+// Lookup returns the named lexicon or an *UnknownLexiconError
+func (r *Registry) Lookup(name string) (*Lexicon, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	l, ok := r.lexicons[name]
+	if !ok {
+		return nil, &UnknownLexiconError{Name: name}
+	}
+	return l, nil
+}
+
+// Names returns the names of the registered lexicons in order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.lexicons))
+	for name := range r.lexicons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,76 @@
+package wordlist
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexiconLoadsLazily(t *testing.T) {
+	opened := 0
+	lexicon := NewLexicon("test", core.English, func() (io.ReadCloser, error) {
+		opened++
+		return ioutil.NopCloser(strings.NewReader("cat\ndog\n")), nil
+	})
+	assert.Equal(t, 0, opened)
+
+	assert.True(t, lexicon.Contains(core.MakeWord("cat")))
+	assert.False(t, lexicon.Contains(core.MakeWord("cow")))
+	trie, err := lexicon.Trie()
+	assert.NoError(t, err)
+	assert.True(t, trie.Contains(core.MakeWord("dog")))
+	assert.Equal(t, 1, opened)
+
+	_, err = lexicon.Gaddag()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, opened)
+}
+
+func TestLexiconLoadError(t *testing.T) {
+	lexicon := FileLexicon("missing", core.English, filepath.Join(os.TempDir(), "no-such-word-list.txt"))
+	_, err := lexicon.Trie()
+	assert.Error(t, err)
+	assert.False(t, lexicon.Contains(core.MakeWord("cat")))
+}
+
+func TestRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lexicons")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "kids.txt"), []byte("cat\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "notes.md"), []byte("cat\n"), 0644))
+
+	registry := NewRegistry(Default, WordsLexicon("custom", core.English, []byte("zax\n")))
+	assert.NoError(t, registry.RegisterDir(dir, core.English))
+	assert.Equal(t, []string{"custom", "default", "kids"}, registry.Names())
+
+	kids, err := registry.Lookup("kids")
+	if assert.NoError(t, err) {
+		assert.True(t, kids.Contains(core.MakeWord("cat")))
+	}
+	custom, err := registry.Lookup("custom")
+	if assert.NoError(t, err) {
+		assert.True(t, custom.Contains(core.MakeWord("zax")))
+	}
+
+	_, err = registry.Lookup("sowpods")
+	assert.Equal(t, &UnknownLexiconError{Name: "sowpods"}, err)
+}
+
+func TestRegistryAdd(t *testing.T) {
+	r := NewRegistry(Default)
+	assert.Error(t, r.Add(WordsLexicon(Default.Name, core.English, []byte("dog\n"))))
+	assert.NoError(t, r.Add(WordsLexicon("pets", core.English, []byte("dog\n"))))
+	_, err := r.Lookup("pets")
+	assert.NoError(t, err)
+	l, _ := r.Lookup(Default.Name)
+	assert.True(t, l == Default)
+}
@@ -0,0 +1,336 @@
+// Package query answers study questions about a word list: anagrams,
+// patterns, hooks and words containing a substring.
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+)
+
+// Blank stands for any one letter in anagrams and patterns. Wildcard stands
+// for any run of letters, including none, in patterns.
+const (
+	Blank    = '?'
+	Wildcard = '*'
+)
+
+// Tokens of a parsed query which aren't letters
+const (
+	anyLetter core.Letter = -1
+	anyRun    core.Letter = -2
+)
+
+// Options filter and limit the results of a query. Zero values don't filter.
+type Options struct {
+	MinLength      int
+	MaxLength      int
+	MinProbability float64
+	Limit          int
+	// Subanagrams allows anagrams which use only some of the letters
+	Subanagrams bool
+}
+
+// Result is a word found by a query. Probability is the chance of drawing
+// the word's tiles, blanks included, from a full bag.
+type Result struct {
+	Word        string  `json:"word"`
+	Probability float64 `json:"probability"`
+
+	letters int
+}
+
+// Hooks are the letters which can be played before or after a word to make
+// another word
+type Hooks struct {
+	Front []string `json:"front"`
+	Back  []string `json:"back"`
+}
+
+// Searcher runs queries against a trie spelled in a tile set's alphabet
+type Searcher struct {
+	trie  *wordlist.Trie
+	tiles *core.TileSet
+}
+
+func NewSearcher(trie *wordlist.Trie, tiles *core.TileSet) *Searcher {
+	return &Searcher{trie: trie, tiles: tiles}
+}
+
+// Anagrams returns the words which can be made from the letters, where each
+// Blank can be any letter
+func (s *Searcher) Anagrams(letters string, opts Options) ([]Result, error) {
+	tokens, err := s.parse(letters)
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]int, s.tiles.Alphabet.Size())
+	blanks := 0
+	for _, t := range tokens {
+		switch t {
+		case anyRun:
+			return nil, fmt.Errorf("%q: %c is only allowed in patterns", letters, Wildcard)
+		case anyLetter:
+			blanks++
+		default:
+			counts[t]++
+		}
+	}
+
+	c := s.collector(opts)
+	var walk func(node *wordlist.Trie, word core.Word, blanks int)
+	walk = func(node *wordlist.Trie, word core.Word, blanks int) {
+		if node.IsTerminal() && (opts.Subanagrams || len(word) == len(tokens)) {
+			c.add(word)
+		}
+		if c.tooLong(len(word) + 1) {
+			return
+		}
+		for _, l := range s.tiles.Alphabet.Letters() {
+			next, ok := node.CanBranch(l.ToTile(false))
+			if !ok {
+				continue
+			}
+			// a letter's own tile is never worse than a blank
+			if counts[l] > 0 {
+				counts[l]--
+				walk(next, append(word, l), blanks)
+				counts[l]++
+			} else if blanks > 0 {
+				walk(next, append(word, l), blanks-1)
+			}
+		}
+	}
+	walk(s.trie, nil, blanks)
+	return c.results(), nil
+}
+
+// Pattern returns the words matching a pattern of letters, Blanks and
+// Wildcards. Patterns with many Wildcards can take a long time to match, so
+// the search stops with ctx's error when ctx is done.
+func (s *Searcher) Pattern(ctx context.Context, pattern string, opts Options) ([]Result, error) {
+	tokens, err := s.parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return s.match(ctx, tokens, opts)
+}
+
+// Containing returns the words which contain the given letters in order,
+// stopping like Pattern when ctx is done
+func (s *Searcher) Containing(ctx context.Context, substring string, opts Options) ([]Result, error) {
+	tokens, err := s.parse(substring)
+	if err != nil {
+		return nil, err
+	}
+	tokens = append(append([]core.Letter{anyRun}, tokens...), anyRun)
+	return s.match(ctx, collapseRuns(tokens), opts)
+}
+
+// Hooks returns the front and back hooks of a word
+func (s *Searcher) Hooks(word string) (Hooks, error) {
+	letters, err := s.tiles.Alphabet.Parse(word)
+	if err != nil {
+		return Hooks{}, err
+	}
+	hooks := Hooks{Front: []string{}, Back: []string{}}
+	for _, l := range s.tiles.Alphabet.Letters() {
+		if s.trie.Contains(append(core.Word{l}, letters...)) {
+			hooks.Front = append(hooks.Front, s.tiles.Alphabet.LetterString(l))
+		}
+		if s.trie.Contains(append(letters[:len(letters):len(letters)], l)) {
+			hooks.Back = append(hooks.Back, s.tiles.Alphabet.LetterString(l))
+		}
+	}
+	return hooks, nil
+}
+
+// Probability returns the chance of drawing the letters of word from a full
+// bag, counting blanks as any letter
+func (s *Searcher) Probability(word core.Word) float64 {
+	need := map[core.Letter]int{}
+	for _, l := range word {
+		need[l]++
+	}
+	// ways[b] counts the draws of the letters seen so far which use b blanks
+	ways := []float64{1}
+	for l, n := range need {
+		available := 0
+		if int(l) < len(s.tiles.Counts) {
+			available = s.tiles.Counts[l]
+		}
+		next := make([]float64, len(ways)+n)
+		for b, w := range ways {
+			for real := 0; real <= n && real <= available; real++ {
+				next[b+n-real] += w * choose(available, real)
+			}
+		}
+		ways = next
+	}
+
+	draws := 0.0
+	for b, w := range ways {
+		if b <= s.tiles.Blanks {
+			draws += w * choose(s.tiles.Blanks, b)
+		}
+	}
+	return draws / choose(s.tiles.Size(), len(word))
+}
+
+func choose(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 0; i < k; i++ {
+		result = result * float64(n-i) / float64(i+1)
+	}
+	return result
+}
+
+// ctxCheckInterval is how many steps match takes between checks of its ctx
+const ctxCheckInterval = 1 << 12
+
+func (s *Searcher) match(ctx context.Context, tokens []core.Letter, opts Options) ([]Result, error) {
+	c := s.collector(opts)
+	steps := 0
+	var err error
+	var walk func(node *wordlist.Trie, i int, word core.Word)
+	walk = func(node *wordlist.Trie, i int, word core.Word) {
+		if steps++; steps%ctxCheckInterval == 0 && err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			return
+		}
+		if i == len(tokens) {
+			if node.IsTerminal() {
+				c.add(word)
+			}
+			return
+		}
+		switch t := tokens[i]; t {
+		case anyRun:
+			walk(node, i+1, word)
+			fallthrough
+		case anyLetter:
+			if c.tooLong(len(word) + 1) {
+				return
+			}
+			next := i + 1
+			if t == anyRun {
+				next = i
+			}
+			for _, l := range s.tiles.Alphabet.Letters() {
+				if child, ok := node.CanBranch(l.ToTile(false)); ok {
+					walk(child, next, append(word, l))
+				}
+			}
+		default:
+			if child, ok := node.CanBranch(t.ToTile(false)); ok {
+				walk(child, i+1, append(word, t))
+			}
+		}
+	}
+	walk(s.trie, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.results(), nil
+}
+
+// parse splits a query into letters, Blanks and Wildcards. A run of
+// Wildcards matches the same words as one, so runs are collapsed.
+func (s *Searcher) parse(query string) ([]core.Letter, error) {
+	var tokens []core.Letter
+	for len(query) > 0 {
+		i := strings.IndexAny(query, string([]rune{Blank, Wildcard}))
+		if i < 0 {
+			i = len(query)
+		}
+		letters, err := s.tiles.Alphabet.Parse(query[:i])
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, letters...)
+		if i < len(query) {
+			if query[i] == Blank {
+				tokens = append(tokens, anyLetter)
+			} else {
+				tokens = append(tokens, anyRun)
+			}
+			i++
+		}
+		query = query[i:]
+	}
+	return collapseRuns(tokens), nil
+}
+
+// collapseRuns replaces each run of Wildcard tokens with one
+func collapseRuns(tokens []core.Letter) []core.Letter {
+	output := tokens[:0]
+	for _, t := range tokens {
+		if t == anyRun && len(output) > 0 && output[len(output)-1] == anyRun {
+			continue
+		}
+		output = append(output, t)
+	}
+	return output
+}
+
+// collector gathers distinct words which pass the options' filters
+type collector struct {
+	searcher *Searcher
+	opts     Options
+	seen     map[string]bool
+	found    []Result
+}
+
+func (s *Searcher) collector(opts Options) *collector {
+	return &collector{searcher: s, opts: opts, seen: map[string]bool{}}
+}
+
+func (c *collector) tooLong(length int) bool {
+	return c.opts.MaxLength > 0 && length > c.opts.MaxLength
+}
+
+func (c *collector) add(word core.Word) {
+	if len(word) == 0 || len(word) < c.opts.MinLength || c.tooLong(len(word)) {
+		return
+	}
+	text := c.searcher.tiles.Alphabet.WordString(word)
+	if c.seen[text] {
+		return
+	}
+	c.seen[text] = true
+	probability := c.searcher.Probability(word)
+	if probability < c.opts.MinProbability {
+		return
+	}
+	c.found = append(c.found, Result{Word: text, Probability: probability, letters: len(word)})
+}
+
+// results returns the words found, longest and then most probable first
+func (c *collector) results() []Result {
+	sort.Slice(c.found, func(i, j int) bool {
+		a, b := c.found[i], c.found[j]
+		if a.letters != b.letters {
+			return a.letters > b.letters
+		}
+		if a.Probability != b.Probability {
+			return a.Probability > b.Probability
+		}
+		return a.Word < b.Word
+	})
+	if c.opts.Limit > 0 && len(c.found) > c.opts.Limit {
+		c.found = c.found[:c.opts.Limit]
+	}
+	if c.found == nil {
+		return []Result{}
+	}
+	return c.found
+}
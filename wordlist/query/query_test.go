@@ -0,0 +1,119 @@
+package query
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/Logiraptor/word-bot/wordlist"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSearcher(words ...string) *Searcher {
+	trie, err := wordlist.LoadTrie(core.English, strings.NewReader(strings.Join(words, "\n")))
+	if err != nil {
+		panic(err)
+	}
+	return NewSearcher(trie, core.EnglishScrabble)
+}
+
+func words(results []Result) []string {
+	output := make([]string, len(results))
+	for i, r := range results {
+		output[i] = r.Word
+	}
+	return output
+}
+
+func TestAnagrams(t *testing.T) {
+	s := newSearcher("stare", "tears", "rates", "aster", "star", "rat", "tsar", "treats")
+
+	results, err := s.Anagrams("RATES", Options{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"stare", "tears", "rates", "aster"}, words(results))
+
+	results, err = s.Anagrams("rat?", Options{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"star", "tsar"}, words(results))
+
+	results, err = s.Anagrams("rats", Options{Subanagrams: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"star", "tsar", "rat"}, words(results), "longest first")
+
+	results, err = s.Anagrams("rats", Options{Subanagrams: true, MinLength: 4})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"star", "tsar"}, words(results))
+
+	_, err = s.Anagrams("ra*", Options{})
+	assert.Error(t, err)
+}
+
+func TestPattern(t *testing.T) {
+	s := newSearcher("rate", "rare", "bake", "babe", "baked", "cafe", "ace")
+
+	results, err := s.Pattern(context.Background(), "?A?E", Options{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"rate", "rare", "bake", "babe", "cafe"}, words(results))
+
+	results, err = s.Pattern(context.Background(), "ba*", Options{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"baked"}, words(results)[:1])
+	assert.Len(t, results, 2)
+
+	results, err = s.Pattern(context.Background(), "*a*e*", Options{MaxLength: 4})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"rate", "rare", "bake", "babe", "cafe", "ace"}, words(results))
+}
+
+func TestContaining(t *testing.T) {
+	s := newSearcher("bake", "baked", "cake", "ache", "keel")
+
+	results, err := s.Containing(context.Background(), "ake", Options{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"bake", "baked", "cake"}, words(results))
+}
+
+func TestHooks(t *testing.T) {
+	s := newSearcher("at", "cat", "bat", "ate", "ats")
+
+	hooks, err := s.Hooks("at")
+	assert.NoError(t, err)
+	assert.Equal(t, Hooks{Front: []string{"b", "c"}, Back: []string{"e", "s"}}, hooks)
+}
+
+func TestProbability(t *testing.T) {
+	s := newSearcher()
+	// one way to draw the q or either blank, out of 100 tiles
+	assert.InDelta(t, 3.0/100, s.Probability(core.MakeWord("q")), 1e-9)
+	// 9 a's and 2 blanks
+	assert.InDelta(t, choose(11, 2)/choose(100, 2), s.Probability(core.MakeWord("aa")), 1e-9)
+
+	results, err := newSearcher("aa", "zz", "qi").Pattern(context.Background(), "??", Options{MinProbability: 0.001})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aa", "qi"}, words(results))
+	assert.True(t, results[0].Probability > results[1].Probability)
+	assert.False(t, math.IsNaN(results[0].Probability))
+}
+
+func TestPatternWildcardRuns(t *testing.T) {
+	s := newSearcher("rate", "rare", "bake", "ace")
+	tokens, err := s.parse("**a***e**")
+	assert.NoError(t, err)
+	assert.Equal(t, []core.Letter{anyRun, core.Rune2Letter('a'), anyRun, core.Rune2Letter('e'), anyRun}, tokens)
+
+	results, err := s.Pattern(context.Background(), "**a***e", Options{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"rate", "rare", "bake", "ace"}, words(results))
+}
+
+func TestPatternStopsWhenDone(t *testing.T) {
+	s := NewSearcher(wordlist.MakeDefaultWordList(), core.EnglishScrabble)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.Pattern(ctx, "*a*e*i*", Options{})
+	assert.Equal(t, context.Canceled, err)
+	_, err = s.Containing(ctx, "e", Options{})
+	assert.Equal(t, context.Canceled, err)
+}
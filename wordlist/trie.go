@@ -3,6 +3,7 @@ package wordlist
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	"github.com/Logiraptor/word-bot/core"
 	"github.com/Logiraptor/word-bot/definitions"
@@ -10,9 +11,16 @@ import (
 
 //go:generate go-bindata -pkg wordlist -o ./words.go ./words.txt
 
+// MakeDefaultWordList returns the trie of the embedded word list. If a
+// prebuilt TrieFile is found in the directory named by GraphDirEnv, or else
+// next to the executable, it is loaded instead of building the trie. Files
+// built from another version of the list are ignored.
 func MakeDefaultWordList() *Trie {
-	builder := NewTrieBuilder(151434)
 	buf := MustAsset("words.txt")
+	if root, ok := loadPrebuilt(TrieFile, trieKind, buf); ok {
+		return (*Trie)(root)
+	}
+	builder := NewTrieBuilder(79340)
 	err := definitions.LoadDefinitionsReader(bytes.NewReader(buf), builder)
 	if err != nil {
 		panic(fmt.Sprintf("Cannot load embedded word list file"))
@@ -20,95 +28,122 @@ func MakeDefaultWordList() *Trie {
 	return builder.Build()
 }
 
+// MakeDefaultWordListGaddag returns the gaddag of the embedded word list,
+// loading a prebuilt GaddagFile if there is one like MakeDefaultWordList.
 func MakeDefaultWordListGaddag() *Gaddag {
-	builder := NewGaddag()
 	buf := MustAsset("words.txt")
+	if root, ok := loadPrebuilt(GaddagFile, gaddagKind, buf); ok {
+		return (*Gaddag)(root)
+	}
+	builder := NewGaddagBuilder()
 	err := definitions.LoadDefinitionsReader(bytes.NewReader(buf), builder)
 	if err != nil {
 		panic(fmt.Sprintf("Cannot load embedded word list file"))
 	}
-	return builder
+	return builder.Build()
+}
+
+// LoadTrie builds a Trie from a newline separated word list, splitting each
+// word into letters with the given alphabet. Words that cannot be spelled
+// with the alphabet are skipped.
+func LoadTrie(alphabet *core.Alphabet, r io.Reader) (*Trie, error) {
+	builder := NewTrieBuilder(0)
+	err := definitions.LoadDefinitionsReader(r, alphabetWordDB{alphabet, builder})
+	if err != nil {
+		return nil, err
+	}
+	return builder.Build(), nil
+}
+
+// letterDB is implemented by word graphs which accept pre-parsed words
+type letterDB interface {
+	AddLetters(word core.Word)
 }
 
-type Trie struct {
-	nodes    [26]*Trie
-	terminal bool
+// alphabetWordDB parses words with an alphabet before adding them to a letterDB
+type alphabetWordDB struct {
+	alphabet *core.Alphabet
+	db       letterDB
+}
+
+func (a alphabetWordDB) AddWord(s string) {
+	word, err := a.alphabet.Parse(s)
+	if err != nil {
+		return
+	}
+	a.db.AddLetters(word)
 }
 
+// Trie is a word graph which reads words from left to right. Tries built by
+// a TrieBuilder or the loaders are minimized, sharing common suffixes, and
+// can no longer be added to.
+type Trie node
+
 func NewTrie() *Trie {
 	return &Trie{}
 }
 
 func (t *Trie) Contains(word core.Word) bool {
-	current := t
+	current := (*node)(t)
 	for _, letter := range word {
-		if current.nodes[letter] == nil {
+		if current = current.child(letter); current == nil {
 			return false
 		}
-		current = current.nodes[letter]
 	}
-	return current.terminal
+	return current.isTerminal()
 }
 
 func (t *Trie) IsTerminal() bool {
-	return t.terminal
+	return (*node)(t).isTerminal()
 }
 
 func (t *Trie) CanBranch(tile core.Tile) (*Trie, bool) {
-	next := t.nodes[tile.ToLetter()]
-	return next, next != nil
+	next := (*node)(t).child(tile.ToLetter())
+	return (*Trie)(next), next != nil
 }
 
+// AddWord adds a word spelled in the English alphabet
 func (t *Trie) AddWord(word string) {
-	current := t
-	for _, letter := range word {
-		i := core.Rune2Letter(letter)
-		if current.nodes[i] == nil {
-			current.nodes[i] = NewTrie()
-		}
+	t.AddLetters(core.MakeWord(word))
+}
 
-		current = current.nodes[i]
+// AddLetters adds a word which has already been split into letters
+func (t *Trie) AddLetters(word core.Word) {
+	current := (*node)(t)
+	for _, letter := range word {
+		current = current.add(letter)
 	}
-
-	current.terminal = true
+	current.mask |= terminalBit
 }
 
+// TrieBuilder collects words and builds a minimized Trie from them
 type TrieBuilder struct {
-	nodes []Trie
-	ptr   int
+	graph   graphBuilder
+	letters []byte
 }
 
+// NewTrieBuilder returns a builder with room for size words
 func NewTrieBuilder(size int) *TrieBuilder {
-	nodes := make([]Trie, size)
-	nodes = append(nodes, Trie{})
 	return &TrieBuilder{
-		nodes: nodes,
-		ptr:   1,
+		graph: graphBuilder{words: make([]span, 0, size)},
 	}
 }
 
-func (t *TrieBuilder) allocateTrie() *Trie {
-	t.ptr++
-	if t.ptr >= len(t.nodes) {
-		t.nodes = append(t.nodes, Trie{})
-		t.nodes = t.nodes[:cap(t.nodes)]
-	}
-	return &t.nodes[t.ptr]
+// AddWord adds a word spelled in the English alphabet
+func (t *TrieBuilder) AddWord(word string) {
+	t.AddLetters(core.MakeWord(word))
 }
 
-func (t *TrieBuilder) AddWord(word string) {
-	current := &t.nodes[0]
+// AddLetters adds a word which has already been split into letters
+func (t *TrieBuilder) AddLetters(word core.Word) {
+	t.letters = t.letters[:0]
 	for _, letter := range word {
-		i := core.Rune2Letter(letter)
-		if current.nodes[i] == nil {
-			current.nodes[i] = t.allocateTrie()
-		}
-		current = current.nodes[i]
+		t.letters = append(t.letters, byte(letter))
 	}
-
-	current.terminal = true
+	t.graph.add(t.letters)
 }
 
+// Build returns the minimized Trie of every word added so far and resets the builder
 func (t *TrieBuilder) Build() *Trie {
-	return &t.nodes[0]
+	return (*Trie)(t.graph.build())
 }
@@ -0,0 +1,117 @@
+package wordlist
+
+import (
+	"github.com/Logiraptor/word-bot/core"
+)
+
+// Kinds of disagreement between a trie and a gaddag
+const (
+	// NotInTrie means the gaddag accepts a word the trie doesn't contain
+	NotInTrie = "not in trie"
+	// NotInGaddag means the gaddag doesn't accept a trie word split at Split
+	NotInGaddag = "not in gaddag"
+	// BadSplit means the gaddag accepts a path which doesn't cross
+	// reverseToken exactly once, with at least one letter before it
+	BadSplit = "bad split"
+)
+
+// Discrepancy is a word on which a trie and a gaddag disagree. The gaddag
+// path for a split reads Word[Split:], then reverseToken, then Word[:Split]
+// backwards. Split is -1 for paths which can't be read that way.
+type Discrepancy struct {
+	Kind  string
+	Word  core.Word
+	Split int
+}
+
+// Verify checks that the gaddag accepts exactly the words of the trie, each
+// at every split point, returning every disagreement found
+func Verify(trie *Trie, gaddag *Gaddag) []Discrepancy {
+	var found []Discrepancy
+
+	// every gaddag path must spell a trie word
+	var path []core.Letter
+	var walkGaddag func(n *node)
+	walkGaddag = func(n *node) {
+		if n.isTerminal() {
+			if word, split, ok := unsplit(path); !ok {
+				found = append(found, Discrepancy{Kind: BadSplit, Word: word, Split: split})
+			} else if !trie.Contains(word) {
+				found = append(found, Discrepancy{Kind: NotInTrie, Word: word, Split: split})
+			}
+		}
+		n.each(func(l core.Letter, child *node) {
+			path = append(path, l)
+			walkGaddag(child)
+			path = path[:len(path)-1]
+		})
+	}
+	walkGaddag((*node)(gaddag))
+
+	// and every trie word must be in the gaddag at every split
+	var word core.Word
+	var walkTrie func(n *node)
+	walkTrie = func(n *node) {
+		if n.isTerminal() {
+			for split := range word {
+				if !gaddag.containsSplit(word, split) {
+					found = append(found, Discrepancy{Kind: NotInGaddag, Word: append(core.Word{}, word...), Split: split})
+				}
+			}
+		}
+		n.each(func(l core.Letter, child *node) {
+			if l == reverseToken {
+				return
+			}
+			word = append(word, l)
+			walkTrie(child)
+			word = word[:len(word)-1]
+		})
+	}
+	walkTrie((*node)(trie))
+	return found
+}
+
+// unsplit recovers the word and split point spelled by a gaddag path. If
+// the path doesn't cross reverseToken exactly once its letters are returned
+// as they are, split at -1.
+func unsplit(path []core.Letter) (core.Word, int, bool) {
+	sep, seps := -1, 0
+	word := make(core.Word, 0, len(path))
+	for i, l := range path {
+		if l == reverseToken {
+			sep = i
+			seps++
+		} else {
+			word = append(word, l)
+		}
+	}
+	if seps != 1 {
+		return word, -1, false
+	}
+	word = word[:0]
+	for i := len(path) - 1; i > sep; i-- {
+		word = append(word, path[i])
+	}
+	word = append(word, path[:sep]...)
+	split := len(path) - 1 - sep
+	return word, split, sep > 0
+}
+
+func (g *Gaddag) containsSplit(word core.Word, split int) bool {
+	n := (*node)(g)
+	for _, l := range word[split:] {
+		if n = n.child(l); n == nil {
+			return false
+		}
+	}
+	if n = n.child(reverseToken); n == nil {
+		return false
+	}
+	for i := split - 1; i >= 0; i-- {
+		if n = n.child(word[i]); n == nil {
+			return false
+		}
+	}
+	return n.isTerminal()
+}
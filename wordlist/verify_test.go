@@ -0,0 +1,32 @@
+package wordlist
+
+import (
+	"testing"
+
+	"github.com/Logiraptor/word-bot/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyDefaultWordList(t *testing.T) {
+	found := Verify(MakeDefaultWordList(), MakeDefaultWordListGaddag())
+	assert.Empty(t, found)
+}
+
+func TestVerifyReportsDiscrepancies(t *testing.T) {
+	trie := NewTrie()
+	trie.AddWord("cab")
+	trie.AddWord("ab")
+
+	gaddag := NewGaddag()
+	gaddag.AddWord("cab")
+	gaddag.AddWord("zo")
+	gaddag.insertLinearString(core.MakeWord("a"), core.MakeWord("b"))
+	gaddag.insertLinearString(core.MakeWord("qi"), nil)
+
+	assert.ElementsMatch(t, []Discrepancy{
+		{Kind: NotInTrie, Word: core.MakeWord("zo"), Split: 0},
+		{Kind: NotInTrie, Word: core.MakeWord("zo"), Split: 1},
+		{Kind: BadSplit, Word: core.MakeWord("qi"), Split: 2},
+		{Kind: NotInGaddag, Word: core.MakeWord("ab"), Split: 0},
+	}, Verify(trie, gaddag))
+}